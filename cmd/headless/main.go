@@ -0,0 +1,99 @@
+// Command headless simule une partie sans fenêtre, pour la CI et les jobs d'équilibrage.
+//
+// Il n'utilise que le package sim, qui n'importe pas ebiten: ni serveur X ni GPU
+// ne sont nécessaires, et le clavier et les manettes ne sont jamais lus.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/input"
+	"github.com/ajkula/shmup/replay"
+	"github.com/ajkula/shmup/sim"
+)
+
+func main() {
+	ticks := flag.Int("ticks", 3600, "number of fixed ticks to simulate")
+	scriptPath := flag.String("script", "", "JSON file mapping tick numbers to input commands")
+	replayPath := flag.String("replay", "", "replay the inputs recorded in this JSONL file")
+	recordPath := flag.String("record", "", "write every game event to this JSONL file")
+	botSeed := flag.Int64("bot", 0, "let the built-in bot play with this seed instead of reading inputs (0 disables it)")
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	sources := 0
+	for _, set := range []bool{*scriptPath != "", *replayPath != "", *botSeed != 0} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		log.Fatal("-script, -replay and -bot are mutually exclusive")
+	}
+
+	cfg, err := configFlags.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	// -print-config affiche aussi une config invalide, pour voir d'où vient l'erreur
+	if configFlags.PrintConfig() {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid config:\n%v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	opts := []sim.Option{sim.WithConfig(cfg)}
+	switch {
+	case *scriptPath != "":
+		data, err := os.ReadFile(*scriptPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		script := map[uint64][]string{}
+		if err := json.Unmarshal(data, &script); err != nil {
+			log.Fatalf("invalid input script: %v", err)
+		}
+		opts = append(opts, sim.WithInputSource(input.NewScripted(script)))
+	case *replayPath != "":
+		source, err := replay.LoadInputSource(*replayPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, sim.WithInputSource(source))
+	case *botSeed != 0:
+		opts = append(opts, sim.WithInputSource(input.NewBot(*botSeed)))
+	}
+	if *recordPath != "" {
+		f, err := os.Create(*recordPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		opts = append(opts, sim.WithRecorder(f))
+	}
+
+	summary, err := sim.RunHeadless(ctx, *ticks, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(summary); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,29 +1,53 @@
+// Package config charge la GameConfig par couches: valeurs par défaut, fichier
+// YAML ou JSON, variables d'environnement SHMUP_*, puis flags de la ligne de commande.
+//
+// Chaque champ a une clé de fichier (tag yaml/json, ex. enemySpawnInterval),
+// dont dérivent la variable d'environnement (SHMUP_ENEMY_SPAWN_INTERVAL)
+// et le flag (-enemy-spawn-interval). Keys liste toutes les clés documentées.
+// Les champs marqués live peuvent être rechargés pendant la partie.
 package config
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"strconv"
+
+	"github.com/ajkula/shmup/input"
 )
 
 type GameConfig struct {
-	ScreenWidth  int
-	ScreenHeight int
-	PlayerSpeed  float64
-	EnemySpeed   float64
-	BulletSpeed  float64
+	ScreenWidth  int     `yaml:"screenWidth" json:"screenWidth" usage:"window width in pixels"`
+	ScreenHeight int     `yaml:"screenHeight" json:"screenHeight" usage:"window height in pixels"`
+	PlayerSpeed  float64 `yaml:"playerSpeed" json:"playerSpeed" usage:"player movement speed in pixels per tick" live:"true"`
+	EnemySpeed   float64 `yaml:"enemySpeed" json:"enemySpeed" usage:"enemy movement speed in pixels per tick" live:"true"`
+	BulletSpeed  float64 `yaml:"bulletSpeed" json:"bulletSpeed" usage:"bullet speed in pixels per tick" live:"true"`
+
+	BossThreshold      int     `yaml:"bossThreshold" json:"bossThreshold" usage:"score needed before a boss appears" live:"true"`
+	EnemySpawnInterval float64 `yaml:"enemySpawnInterval" json:"enemySpawnInterval" usage:"seconds between enemy spawns" live:"true"`
+	PowerUpSpawnChance float64 `yaml:"powerUpSpawnChance" json:"powerUpSpawnChance" usage:"probability in [0,1] that a destroyed enemy drops a power-up" live:"true"`
+
+	Difficulty      string  `yaml:"difficulty" json:"difficulty" usage:"difficulty preset: Easy, Normal, Hard or Lunatic"`
+	Credits         int     `yaml:"credits" json:"credits" usage:"continues available per game"`
+	ContinueSeconds float64 `yaml:"continueSeconds" json:"continueSeconds" usage:"seconds left to continue after a game over" live:"true"`
 
-	BossThreshold      int
-	EnemySpawnInterval float64
-	PowerUpSpawnChance float64
+	GamepadDeadzone float64 `yaml:"gamepadDeadzone" json:"gamepadDeadzone" usage:"analog stick deadzone in [0,1)" live:"true"`
+	Autofire        bool    `yaml:"autofire" json:"autofire" usage:"fire continuously without holding the fire action" live:"true"`
+	// pas de variable d'environnement ni de flag: se modifie dans le fichier ou l'écran d'options
+	Bindings input.Bindings `yaml:"bindings" json:"bindings" usage:"key names bound to each action" live:"true"`
 
-	MaxEventQueueSize int
-	MaxStateQueueSize int
+	MaxEventQueueSize int  `yaml:"maxEventQueueSize" json:"maxEventQueueSize" usage:"maximum number of queued events"`
+	MaxStateQueueSize int  `yaml:"maxStateQueueSize" json:"maxStateQueueSize" usage:"maximum number of queued state changes"`
+	SyncEventDispatch bool `yaml:"syncEventDispatch" json:"syncEventDispatch" usage:"dispatch events at the start of each tick instead of asynchronously"`
 }
 
-var Config GameConfig
+// Config est la configuration globale historique. Elle ne sert plus que de
+// valeur par défaut quand aucune config n'est injectée (WithContext, options).
+var Config = Default()
 
-func Init() {
-	Config = GameConfig{
+// Default renvoie la configuration intégrée, première couche de Load
+func Default() GameConfig {
+	return GameConfig{
 		ScreenWidth:        640,
 		ScreenHeight:       928,
 		PlayerSpeed:        5.0,
@@ -32,25 +56,77 @@ func Init() {
 		BossThreshold:      50,
 		EnemySpawnInterval: 2.0,
 		PowerUpSpawnChance: 0.1,
-		MaxEventQueueSize:  100,
+		Difficulty:         DefaultDifficulty,
+		Credits:            3,
+		ContinueSeconds:    10,
+		Bindings:           input.DefaultBindings(),
+		GamepadDeadzone:    input.DefaultDeadzone,
+		MaxEventQueueSize:  5000,
 		MaxStateQueueSize:  10,
+		SyncEventDispatch:  false,
 	}
 }
 
-func getEnvInt(key string, defaultValue int) int {
-	if value, exists := os.LookupEnv(key); exists {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
-		}
+func Init() {
+	Config = Default()
+}
+
+type contextKey struct{}
+
+// WithContext attache cfg au contexte transmis aux systèmes par Initialize
+func WithContext(ctx context.Context, cfg GameConfig) context.Context {
+	return context.WithValue(ctx, contextKey{}, cfg)
+}
+
+// FromContext renvoie la config attachée à ctx, ou le global Config à défaut
+func FromContext(ctx context.Context) GameConfig {
+	if cfg, ok := ctx.Value(contextKey{}).(GameConfig); ok {
+		return cfg
 	}
-	return defaultValue
+	return Config
 }
 
-func getEnvFloat(key string, defaultValue float64) float64 {
+// les getEnv* renvoient defaultValue si la variable est absente, et une
+// erreur qui nomme la variable si sa valeur ne se lit pas
+func getEnvInt(key string, defaultValue int) (int, error) {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue, nil
+	}
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue, fmt.Errorf("invalid %s=%q: expected an integer", key, value)
+	}
+	return intValue, nil
+}
+
+func getEnvFloat(key string, defaultValue float64) (float64, error) {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue, nil
+	}
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultValue, fmt.Errorf("invalid %s=%q: expected a number", key, value)
+	}
+	return floatValue, nil
+}
+
+func getEnvString(key string, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
-		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
-			return floatValue
-		}
+		return value
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) (bool, error) {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue, nil
+	}
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue, fmt.Errorf("invalid %s=%q: expected true or false", key, value)
+	}
+	return boolValue, nil
+}
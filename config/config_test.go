@@ -0,0 +1,234 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestKeysNaming(t *testing.T) {
+	for _, key := range Keys() {
+		if key.Name == "" || key.Usage == "" {
+			t.Errorf("field %s is not documented: %+v", key.Field, key)
+		}
+		if key.Field == "EnemySpawnInterval" {
+			if key.Env != "SHMUP_ENEMY_SPAWN_INTERVAL" || key.Flag != "enemy-spawn-interval" {
+				t.Errorf("unexpected names for EnemySpawnInterval: %+v", key)
+			}
+		}
+	}
+}
+
+func TestLoadLayers(t *testing.T) {
+	path := writeFile(t, "shmup.yaml", "bossThreshold: 80\nenemySpawnInterval: 1.5\nbulletSpeed: 12\n")
+	t.Setenv("SHMUP_ENEMY_SPAWN_INTERVAL", "1.25")
+	t.Setenv("SHMUP_BULLET_SPEED", "14")
+	t.Setenv("SHMUP_SYNC_EVENT_DISPATCH", "true")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := RegisterFlags(fs)
+	if err := fs.Parse([]string{"-config", path, "-bullet-speed", "16"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := flags.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.ScreenWidth != Default().ScreenWidth {
+		t.Errorf("ScreenWidth = %d, want the default", cfg.ScreenWidth)
+	}
+	if cfg.BossThreshold != 80 {
+		t.Errorf("BossThreshold = %d, want 80 from the file", cfg.BossThreshold)
+	}
+	if cfg.EnemySpawnInterval != 1.25 {
+		t.Errorf("EnemySpawnInterval = %v, want 1.25 from the environment", cfg.EnemySpawnInterval)
+	}
+	if !cfg.SyncEventDispatch {
+		t.Error("SyncEventDispatch should be set from the environment")
+	}
+	if cfg.BulletSpeed != 16 {
+		t.Errorf("BulletSpeed = %v, want 16 from the flag", cfg.BulletSpeed)
+	}
+}
+
+func TestLoadRejectsBadEnvValues(t *testing.T) {
+	for env, value := range map[string]string{
+		"SHMUP_PLAYER_SPEED":        "fast",
+		"SHMUP_BOSS_THRESHOLD":      "12.5",
+		"SHMUP_SYNC_EVENT_DISPATCH": "sometimes",
+	} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv(env, value)
+			flags := RegisterFlags(flag.NewFlagSet("test", flag.ContinueOnError))
+			_, err := flags.Load()
+			if err == nil {
+				t.Fatalf("Load should reject %s=%s", env, value)
+			}
+			if !strings.Contains(err.Error(), env) || !strings.Contains(err.Error(), value) {
+				t.Errorf("error should name the variable and the value, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadFileJSONAndErrors(t *testing.T) {
+	cfg := Default()
+	if err := LoadFile(writeFile(t, "shmup.json", `{"credits": 5}`), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Credits != 5 {
+		t.Errorf("Credits = %d, want 5", cfg.Credits)
+	}
+
+	for name, content := range map[string]string{
+		"unknown.yaml": "bossTreshold: 10\n",
+		"unknown.json": `{"bossTreshold": 10}`,
+		"shmup.toml":   "credits = 5\n",
+	} {
+		if err := LoadFile(writeFile(t, name, content), &cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPrintRoundTrip(t *testing.T) {
+	cfg := Default()
+	cfg.EnemySpawnInterval = 0.75
+
+	var buf bytes.Buffer
+	if err := cfg.Print(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded := Default()
+	if err := LoadFile(writeFile(t, "printed.yaml", buf.String()), &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, cfg) {
+		t.Errorf("round trip: got %+v, want %+v", loaded, cfg)
+	}
+}
+
+func TestValidateDefaults(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf("default config should be valid: %v", err)
+	}
+}
+
+func TestValidateListsEveryViolation(t *testing.T) {
+	cfg := Default()
+	cfg.ScreenWidth = 0
+	cfg.BulletSpeed = -1
+	cfg.PowerUpSpawnChance = 1.5
+	cfg.MaxEventQueueSize = 0
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+
+	var fields []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var fieldErr *FieldError
+		if !errors.As(e, &fieldErr) {
+			t.Fatalf("unexpected error type %T", e)
+		}
+		fields = append(fields, fieldErr.Field)
+	}
+	expected := []string{"ScreenWidth", "BulletSpeed", "PowerUpSpawnChance", "MaxEventQueueSize"}
+	if strings.Join(fields, ",") != strings.Join(expected, ",") {
+		t.Errorf("violations = %v, want %v", fields, expected)
+	}
+
+	// la contrainte croisée s'applique avec un écran valide
+	cfg = Default()
+	cfg.PlayerSpeed = float64(cfg.ScreenWidth)
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "PlayerSpeed") {
+		t.Errorf("expected a PlayerSpeed versus screen size error, got %v", err)
+	}
+}
+
+func TestApplyLive(t *testing.T) {
+	current := Default()
+	next := current
+	next.EnemySpeed = 3
+	next.EnemySpawnInterval = 1
+	next.ScreenWidth = 800
+	next.SyncEventDispatch = true
+
+	merged, rejected := ApplyLive(current, next)
+
+	if merged.EnemySpeed != 3 || merged.EnemySpawnInterval != 1 {
+		t.Errorf("live fields not applied: %+v", merged)
+	}
+	if merged.ScreenWidth != current.ScreenWidth || merged.SyncEventDispatch != current.SyncEventDispatch {
+		t.Errorf("non-live fields applied: %+v", merged)
+	}
+	if strings.Join(rejected, ",") != "ScreenWidth,SyncEventDispatch" {
+		t.Errorf("rejected = %v", rejected)
+	}
+}
+
+func TestWatcherPoll(t *testing.T) {
+	path := writeFile(t, "shmup.yaml", "enemySpeed: 3\n")
+	load := func() (GameConfig, error) {
+		cfg := Default()
+		return cfg, LoadFile(path, &cfg)
+	}
+	w := NewWatcher(path, load)
+
+	if _, changed, err := w.Poll(); changed || err != nil {
+		t.Fatalf("unchanged file: changed=%v err=%v", changed, err)
+	}
+
+	if err := os.WriteFile(path, []byte("enemySpeed: 4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, changed, err := w.Poll()
+	if err != nil || !changed {
+		t.Fatalf("modified file: changed=%v err=%v", changed, err)
+	}
+	if cfg.EnemySpeed != 4 {
+		t.Errorf("EnemySpeed = %v, want 4", cfg.EnemySpeed)
+	}
+	if _, changed, _ := w.Poll(); changed {
+		t.Error("a second Poll without modification should report no change")
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if got := FromContext(context.Background()); !reflect.DeepEqual(got, Config) {
+		t.Errorf("FromContext without a config should return the global, got %+v", got)
+	}
+
+	cfg := Default()
+	cfg.ScreenWidth = 320
+	ctx := WithContext(context.Background(), cfg)
+	if got := FromContext(ctx); got.ScreenWidth != 320 {
+		t.Errorf("FromContext: ScreenWidth = %d, want 320", got.ScreenWidth)
+	}
+	if Config.ScreenWidth == 320 {
+		t.Error("WithContext must not change the global config")
+	}
+}
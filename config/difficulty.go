@@ -0,0 +1,60 @@
+package config
+
+import "strings"
+
+// DifficultyPreset regroupe les multiplicateurs appliqués aux statistiques de base
+type DifficultyPreset struct {
+	Name string
+	// vie des ennemis et des boss
+	EnemyHealth float64
+	// délai entre deux tirs ennemis (maxCooldown): plus petit = plus de tirs
+	FireCooldown float64
+	// vitesse des balles ennemies
+	BulletSpeed float64
+	// délai entre deux apparitions d'ennemis
+	SpawnInterval   float64
+	ScoreMultiplier float64
+}
+
+const DefaultDifficulty = "Normal"
+
+var difficultyPresets = []DifficultyPreset{
+	{Name: "Easy", EnemyHealth: 0.75, FireCooldown: 1.5, BulletSpeed: 0.8, SpawnInterval: 1.3, ScoreMultiplier: 0.5},
+	{Name: "Normal", EnemyHealth: 1, FireCooldown: 1, BulletSpeed: 1, SpawnInterval: 1, ScoreMultiplier: 1},
+	{Name: "Hard", EnemyHealth: 1.5, FireCooldown: 0.75, BulletSpeed: 1.2, SpawnInterval: 0.8, ScoreMultiplier: 1.5},
+	{Name: "Lunatic", EnemyHealth: 2, FireCooldown: 0.5, BulletSpeed: 1.5, SpawnInterval: 0.6, ScoreMultiplier: 2.5},
+}
+
+// DifficultyPresets renvoie les presets du plus facile au plus difficile
+func DifficultyPresets() []DifficultyPreset {
+	return append([]DifficultyPreset{}, difficultyPresets...)
+}
+
+// LookupDifficulty cherche un preset par nom, sans tenir compte de la casse
+func LookupDifficulty(name string) (DifficultyPreset, bool) {
+	for _, preset := range difficultyPresets {
+		if strings.EqualFold(preset.Name, name) {
+			return preset, true
+		}
+	}
+	return DifficultyPreset{}, false
+}
+
+// NextDifficulty renvoie le preset suivant, en revenant au premier après le dernier
+func NextDifficulty(current DifficultyPreset) DifficultyPreset {
+	for i, preset := range difficultyPresets {
+		if preset.Name == current.Name {
+			return difficultyPresets[(i+1)%len(difficultyPresets)]
+		}
+	}
+	return difficultyPresets[0]
+}
+
+// DifficultyPreset renvoie le preset choisi, Normal si le nom est inconnu
+func (c GameConfig) DifficultyPreset() DifficultyPreset {
+	if preset, ok := LookupDifficulty(c.Difficulty); ok {
+		return preset
+	}
+	preset, _ := LookupDifficulty(DefaultDifficulty)
+	return preset
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestDifficultyPresets(t *testing.T) {
+	if got := Default().DifficultyPreset().Name; got != "Normal" {
+		t.Errorf("default difficulty = %s, want Normal", got)
+	}
+	if preset, ok := LookupDifficulty("lunatic"); !ok || preset.Name != "Lunatic" {
+		t.Errorf("LookupDifficulty should ignore case, got %+v", preset)
+	}
+
+	presets := DifficultyPresets()
+	for i, preset := range presets {
+		if next := NextDifficulty(preset); next.Name != presets[(i+1)%len(presets)].Name {
+			t.Errorf("NextDifficulty(%s) = %s", preset.Name, next.Name)
+		}
+	}
+
+	cfg := Default()
+	cfg.Difficulty = "Nightmare"
+	if err := cfg.Validate(); err == nil {
+		t.Error("an unknown difficulty should not validate")
+	}
+}
+
+func TestDifficultyFromEnvAndFlag(t *testing.T) {
+	t.Setenv("SHMUP_DIFFICULTY", "Hard")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := RegisterFlags(fs)
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := flags.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Difficulty != "Hard" {
+		t.Errorf("Difficulty = %s, want Hard from the environment", cfg.Difficulty)
+	}
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	flags = RegisterFlags(fs)
+	if err := fs.Parse([]string{"-difficulty", "Easy"}); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, _ = flags.Load(); cfg.Difficulty != "Easy" {
+		t.Errorf("Difficulty = %s, want Easy from the flag", cfg.Difficulty)
+	}
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+const EnvPrefix = "SHMUP_"
+
+// Key documente une clé de configuration et ses trois noms
+type Key struct {
+	Field string
+	Name  string
+	Env   string
+	Flag  string
+	Usage string
+	Live  bool
+}
+
+// Keys renvoie les clés de GameConfig dans l'ordre de déclaration
+func Keys() []Key {
+	t := reflect.TypeOf(GameConfig{})
+	keys := make([]Key, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("yaml")
+		keys = append(keys, Key{
+			Field: field.Name,
+			Name:  name,
+			Env:   EnvPrefix + strings.ToUpper(splitWords(name, "_")),
+			Flag:  splitWords(name, "-"),
+			Usage: field.Tag.Get("usage"),
+			Live:  field.Tag.Get("live") == "true",
+		})
+	}
+	return keys
+}
+
+// splitWords découpe une clé camelCase: "enemySpawnInterval" -> "enemy-spawn-interval"
+func splitWords(name, sep string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// LoadFile applique un fichier YAML (.yaml, .yml) ou JSON (.json) sur cfg.
+// Les clés absentes gardent leur valeur, les clés inconnues sont une erreur.
+func LoadFile(path string, cfg *GameConfig) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && err != io.EOF {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(cfg); err != nil {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("unsupported config file %s: expected .yaml, .yml or .json", path)
+	}
+	return nil
+}
+
+// ApplyEnv applique les variables SHMUP_* définies sur cfg. Une valeur
+// illisible est une erreur plutôt qu'un retour silencieux à la valeur courante.
+func ApplyEnv(cfg *GameConfig) error {
+	v := reflect.ValueOf(cfg).Elem()
+	var errs []error
+	for i, key := range Keys() {
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Int:
+			value, err := getEnvInt(key.Env, int(field.Int()))
+			errs = append(errs, err)
+			field.SetInt(int64(value))
+		case reflect.Float64:
+			value, err := getEnvFloat(key.Env, field.Float())
+			errs = append(errs, err)
+			field.SetFloat(value)
+		case reflect.Bool:
+			value, err := getEnvBool(key.Env, field.Bool())
+			errs = append(errs, err)
+			field.SetBool(value)
+		case reflect.String:
+			field.SetString(getEnvString(key.Env, field.String()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Flags déclare un flag par clé, plus -config et -print-config
+type Flags struct {
+	fs          *flag.FlagSet
+	values      GameConfig
+	path        string
+	printConfig bool
+}
+
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{fs: fs, values: Default()}
+	fs.StringVar(&f.path, "config", "", "YAML or JSON config file")
+	fs.BoolVar(&f.printConfig, "print-config", false, "print the effective merged config as YAML and exit")
+
+	v := reflect.ValueOf(&f.values).Elem()
+	for i, key := range Keys() {
+		switch ptr := v.Field(i).Addr().Interface().(type) {
+		case *int:
+			fs.IntVar(ptr, key.Flag, *ptr, key.Usage)
+		case *float64:
+			fs.Float64Var(ptr, key.Flag, *ptr, key.Usage)
+		case *bool:
+			fs.BoolVar(ptr, key.Flag, *ptr, key.Usage)
+		case *string:
+			fs.StringVar(ptr, key.Flag, *ptr, key.Usage)
+		}
+	}
+	return f
+}
+
+// Path renvoie le fichier passé par -config, ou DefaultFile s'il existe
+func (f *Flags) Path() string {
+	if f.path != "" {
+		return f.path
+	}
+	if _, err := os.Stat(DefaultFile); err == nil {
+		return DefaultFile
+	}
+	return ""
+}
+
+func (f *Flags) PrintConfig() bool {
+	return f.printConfig
+}
+
+// Apply copie sur cfg les seuls flags passés sur la ligne de commande
+func (f *Flags) Apply(cfg *GameConfig) {
+	set := make(map[string]bool)
+	f.fs.Visit(func(fl *flag.Flag) {
+		set[fl.Name] = true
+	})
+
+	src := reflect.ValueOf(&f.values).Elem()
+	dst := reflect.ValueOf(cfg).Elem()
+	for i, key := range Keys() {
+		if set[key.Flag] {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+}
+
+// Load fusionne les quatre couches, à appeler après le Parse du FlagSet
+func (f *Flags) Load() (GameConfig, error) {
+	cfg := Default()
+	if path := f.Path(); path != "" {
+		if err := LoadFile(path, &cfg); err != nil {
+			return cfg, err
+		}
+	}
+	if err := ApplyEnv(&cfg); err != nil {
+		return cfg, err
+	}
+	f.Apply(&cfg)
+	return cfg, nil
+}
+
+// Print écrit la configuration au format YAML, relisible par LoadFile
+func (c GameConfig) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c); err != nil {
+		return fmt.Errorf("failed to print config: %w", err)
+	}
+	return encoder.Close()
+}
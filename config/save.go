@@ -0,0 +1,112 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ajkula/shmup/input"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile est lu quand -config n'est pas passé et qu'il existe; l'écran
+// d'options y enregistre les touches et l'autofire
+const DefaultFile = "shmup.yaml"
+
+// SaveBindings écrit la table de touches dans le fichier de config. Les autres
+// clés du fichier sont conservées, le fichier est créé s'il n'existe pas.
+func SaveBindings(path string, bindings input.Bindings) error {
+	return SaveSetting(path, "bindings", bindings)
+}
+
+// SaveSetting écrit une seule clé du fichier de config, comme SaveBindings
+func SaveSetting(path, key string, value interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err = setYAMLKey(data, key, value)
+	case ".json":
+		data, err = setJSONKey(data, key, value)
+	default:
+		return fmt.Errorf("unsupported config file %s: expected .yaml, .yml or .json", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update config file %s: %w", path, err)
+	}
+
+	// écriture atomique: le ConfigReloader ne doit jamais lire un fichier à moitié écrit
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// setYAMLKey passe par yaml.Node pour garder l'ordre des clés et les commentaires
+func setYAMLKey(data []byte, key string, value interface{}) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("top level is not a mapping")
+	}
+
+	var encoded yaml.Node
+	if err := encoded.Encode(value); err != nil {
+		return nil, err
+	}
+	replaced := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			root.Content[i+1] = &encoded
+			replaced = true
+		}
+	}
+	if !replaced {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &encoded)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func setJSONKey(data []byte, key string, value interface{}) ([]byte, error) {
+	fields := map[string]json.RawMessage{}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	fields[key] = encoded
+	out, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ajkula/shmup/input"
+)
+
+func TestSaveBindingsKeepsOtherKeys(t *testing.T) {
+	path := writeFile(t, "shmup.yaml", "# réglages perso\nbossThreshold: 80\nbindings:\n  shoot: [Z]\n")
+	bindings := input.DefaultBindings().Rebind(input.Bomb, "C")
+
+	if err := SaveBindings(path, bindings); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "# réglages perso") {
+		t.Errorf("comments should be preserved:\n%s", data)
+	}
+	cfg := Default()
+	if err := LoadFile(path, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.BossThreshold != 80 {
+		t.Errorf("BossThreshold = %d, want 80 kept from the file", cfg.BossThreshold)
+	}
+	if !slices.Equal(cfg.Bindings[input.Bomb], []string{"C"}) || !slices.Equal(cfg.Bindings[input.Fire], []string{"Space"}) {
+		t.Errorf("saved bindings not reloaded: %v", cfg.Bindings)
+	}
+}
+
+func TestSaveBindingsCreatesJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shmup.json")
+	bindings := input.DefaultBindings().Rebind(input.Fire, "Z")
+
+	if err := SaveBindings(path, bindings); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Default()
+	if err := LoadFile(path, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(cfg.Bindings[input.Fire], []string{"Z"}) {
+		t.Errorf("Fire keys = %v, want [Z]", cfg.Bindings[input.Fire])
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("saved config should be valid: %v", err)
+	}
+}
+
+func TestSaveSettingAutofire(t *testing.T) {
+	path := writeFile(t, "shmup.yaml", "bossThreshold: 80\n")
+
+	if err := SaveSetting(path, "autofire", true); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Default()
+	if err := LoadFile(path, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Autofire || cfg.BossThreshold != 80 {
+		t.Errorf("Autofire = %v, BossThreshold = %d; want true and 80", cfg.Autofire, cfg.BossThreshold)
+	}
+}
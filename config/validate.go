@@ -0,0 +1,59 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FieldError décrit une contrainte violée par un champ de GameConfig
+type FieldError struct {
+	Field   string
+	Value   interface{}
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s (got %v)", e.Field, e.Message, e.Value)
+}
+
+// Validate vérifie chaque champ puis les contraintes entre champs.
+// L'erreur renvoyée regroupe toutes les violations, une par ligne.
+func (c GameConfig) Validate() error {
+	var errs []error
+	check := func(ok bool, field string, value interface{}, message string) {
+		if !ok {
+			errs = append(errs, &FieldError{Field: field, Value: value, Message: message})
+		}
+	}
+
+	check(c.ScreenWidth > 0, "ScreenWidth", c.ScreenWidth, "must be positive")
+	check(c.ScreenHeight > 0, "ScreenHeight", c.ScreenHeight, "must be positive")
+	check(c.PlayerSpeed > 0, "PlayerSpeed", c.PlayerSpeed, "must be positive")
+	check(c.EnemySpeed > 0, "EnemySpeed", c.EnemySpeed, "must be positive")
+	check(c.BulletSpeed > 0, "BulletSpeed", c.BulletSpeed, "must be positive")
+	check(c.BossThreshold > 0, "BossThreshold", c.BossThreshold, "must be positive")
+	check(c.EnemySpawnInterval > 0, "EnemySpawnInterval", c.EnemySpawnInterval, "must be positive")
+	check(c.PowerUpSpawnChance >= 0 && c.PowerUpSpawnChance <= 1, "PowerUpSpawnChance", c.PowerUpSpawnChance, "must be between 0 and 1")
+	_, knownDifficulty := LookupDifficulty(c.Difficulty)
+	check(knownDifficulty, "Difficulty", c.Difficulty, "must be Easy, Normal, Hard or Lunatic")
+	check(c.Credits >= 0, "Credits", c.Credits, "must not be negative")
+	check(c.ContinueSeconds >= 0, "ContinueSeconds", c.ContinueSeconds, "must not be negative")
+	if err := c.Bindings.Validate(); err != nil {
+		check(false, "Bindings", c.Bindings, strings.ReplaceAll(err.Error(), "\n", "; "))
+	}
+	check(c.GamepadDeadzone >= 0 && c.GamepadDeadzone < 1, "GamepadDeadzone", c.GamepadDeadzone, "must be in [0,1)")
+	check(c.MaxEventQueueSize > 0, "MaxEventQueueSize", c.MaxEventQueueSize, "must be positive")
+	check(c.MaxStateQueueSize > 0, "MaxStateQueueSize", c.MaxStateQueueSize, "must be positive")
+
+	// les vitesses sont en pixels par tick (Player.move, Bullet.Update): un
+	// déplacement plus grand que l'écran sauterait par-dessus le terrain
+	if c.ScreenWidth > 0 && c.ScreenHeight > 0 {
+		smallest := float64(min(c.ScreenWidth, c.ScreenHeight))
+		check(c.PlayerSpeed < smallest, "PlayerSpeed", c.PlayerSpeed, fmt.Sprintf("must be less than the smallest screen dimension %v", smallest))
+		check(c.EnemySpeed < smallest, "EnemySpeed", c.EnemySpeed, fmt.Sprintf("must be less than the smallest screen dimension %v", smallest))
+		check(c.BulletSpeed < float64(c.ScreenHeight), "BulletSpeed", c.BulletSpeed, fmt.Sprintf("must be less than ScreenHeight %d", c.ScreenHeight))
+	}
+
+	return errors.Join(errs...)
+}
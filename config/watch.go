@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/ajkula/shmup/event"
+	"github.com/ajkula/shmup/interfaces"
+)
+
+var (
+	ChangeEvent = interfaces.RegisterEventType("ConfigChange", "live config values were reloaded")
+	ChangeTopic = event.NewTopic[GameConfig](ChangeEvent)
+)
+
+// ApplyLive reporte sur current les champs live de next.
+// Les autres champs modifiés sont ignorés et renvoyés par nom.
+func ApplyLive(current, next GameConfig) (GameConfig, []string) {
+	merged := current
+	var rejected []string
+	src := reflect.ValueOf(next)
+	dst := reflect.ValueOf(&merged).Elem()
+	for i, key := range Keys() {
+		if reflect.DeepEqual(dst.Field(i).Interface(), src.Field(i).Interface()) {
+			continue
+		}
+		if key.Live {
+			dst.Field(i).Set(src.Field(i))
+		} else {
+			rejected = append(rejected, key.Field)
+		}
+	}
+	return merged, rejected
+}
+
+// Watcher relit un fichier de config quand sa date de modification change
+type Watcher struct {
+	path    string
+	load    func() (GameConfig, error)
+	modTime time.Time
+}
+
+// NewWatcher surveille path; load refait la fusion complète des couches
+// pour que l'environnement et les flags gardent la priorité sur le fichier
+func NewWatcher(path string, load func() (GameConfig, error)) *Watcher {
+	w := &Watcher{path: path, load: load}
+	if info, err := os.Stat(path); err == nil {
+		w.modTime = info.ModTime()
+	}
+	return w
+}
+
+func (w *Watcher) Path() string {
+	return w.path
+}
+
+// Poll recharge la config si le fichier a changé depuis le dernier appel
+func (w *Watcher) Poll() (GameConfig, bool, error) {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return GameConfig{}, false, fmt.Errorf("failed to watch config file: %w", err)
+	}
+	if info.ModTime().Equal(w.modTime) {
+		return GameConfig{}, false, nil
+	}
+	w.modTime = info.ModTime()
+
+	cfg, err := w.load()
+	if err != nil {
+		return GameConfig{}, false, err
+	}
+	return cfg, true, nil
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Dependency func(r *registration)
+
+// RunsBefore déclare que le système doit être mis à jour avant les systèmes nommés
+func RunsBefore(names ...string) Dependency {
+	return func(r *registration) {
+		r.before = append(r.before, names...)
+	}
+}
+
+// RunsAfter déclare que le système doit être mis à jour après les systèmes nommés
+func RunsAfter(names ...string) Dependency {
+	return func(r *registration) {
+		r.after = append(r.after, names...)
+	}
+}
+
+type registration struct {
+	name   string
+	system System
+	before []string
+	after  []string
+}
+
+// Registry référence les systèmes par nom et les ordonne selon leurs dépendances
+type Registry struct {
+	entries []*registration
+	byName  map[string]*registration
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		entries: make([]*registration, 0),
+		byName:  make(map[string]*registration),
+	}
+}
+
+func (r *Registry) Register(name string, system System, deps ...Dependency) error {
+	if name == "" {
+		return fmt.Errorf("cannot register system %T without a name", system)
+	}
+	if _, exists := r.byName[name]; exists {
+		return fmt.Errorf("system %q already registered", name)
+	}
+	entry := &registration{name: name, system: system}
+	for _, dep := range deps {
+		dep(entry)
+	}
+	r.entries = append(r.entries, entry)
+	r.byName[name] = entry
+	return nil
+}
+
+func (r *Registry) Get(name string) (System, bool) {
+	entry, ok := r.byName[name]
+	if !ok {
+		return nil, false
+	}
+	return entry.system, true
+}
+
+// Lookup renvoie le premier système enregistré du type demandé
+func Lookup[T System](r *Registry) (T, bool) {
+	for _, entry := range r.entries {
+		if sys, ok := entry.system.(T); ok {
+			return sys, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Sort renvoie les noms des systèmes triés topologiquement.
+// À contraintes égales l'ordre d'enregistrement est conservé.
+func (r *Registry) Sort() ([]string, error) {
+	index := make(map[string]int, len(r.entries))
+	for i, entry := range r.entries {
+		index[entry.name] = i
+	}
+
+	edges := make([][]int, len(r.entries))
+	inDegree := make([]int, len(r.entries))
+	addEdge := func(from, to int) {
+		edges[from] = append(edges[from], to)
+		inDegree[to]++
+	}
+	for i, entry := range r.entries {
+		for _, name := range entry.before {
+			j, ok := index[name]
+			if !ok {
+				return nil, fmt.Errorf("system %q runs before unknown system %q", entry.name, name)
+			}
+			addEdge(i, j)
+		}
+		for _, name := range entry.after {
+			j, ok := index[name]
+			if !ok {
+				return nil, fmt.Errorf("system %q runs after unknown system %q", entry.name, name)
+			}
+			addEdge(j, i)
+		}
+	}
+
+	done := make([]bool, len(r.entries))
+	order := make([]string, 0, len(r.entries))
+	for len(order) < len(r.entries) {
+		next := -1
+		for i := range r.entries {
+			if !done[i] && inDegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			var cycle []string
+			for i, entry := range r.entries {
+				if !done[i] {
+					cycle = append(cycle, entry.name)
+				}
+			}
+			return nil, fmt.Errorf("dependency cycle between systems: %s", strings.Join(cycle, ", "))
+		}
+		done[next] = true
+		order = append(order, r.entries[next].name)
+		for _, to := range edges[next] {
+			inDegree[to]--
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+type otherSystem struct {
+	BaseSystem
+}
+
+func TestRegistrySortFollowsDependencies(t *testing.T) {
+	r := NewRegistry()
+	r.Register("render", &BaseSystem{}, RunsAfter("collision"))
+	r.Register("collision", &BaseSystem{}, RunsAfter("input"))
+	r.Register("input", &BaseSystem{})
+	r.Register("events", &BaseSystem{}, RunsBefore("input"))
+
+	order, err := r.Sort()
+	if err != nil {
+		t.Fatalf("Sort returned an error: %v", err)
+	}
+
+	expected := []string{"events", "input", "collision", "render"}
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("Sort order: got %v, want %v", order, expected)
+	}
+}
+
+func TestRegistrySortKeepsRegistrationOrder(t *testing.T) {
+	r := NewRegistry()
+	r.Register("a", &BaseSystem{})
+	r.Register("b", &BaseSystem{})
+	r.Register("c", &BaseSystem{})
+
+	order, err := r.Sort()
+	if err != nil {
+		t.Fatalf("Sort returned an error: %v", err)
+	}
+	if strings.Join(order, ",") != "a,b,c" {
+		t.Errorf("Sort order: got %v, want [a b c]", order)
+	}
+}
+
+func TestRegistrySortDetectsCycle(t *testing.T) {
+	r := NewRegistry()
+	r.Register("a", &BaseSystem{}, RunsAfter("b"))
+	r.Register("b", &BaseSystem{}, RunsAfter("a"))
+
+	if _, err := r.Sort(); err == nil {
+		t.Error("Expected a cycle error")
+	}
+	if _, err := NewSchedulerFromRegistry(r); err == nil {
+		t.Error("Expected NewSchedulerFromRegistry to fail on a cycle")
+	}
+}
+
+func TestRegistryRejectsDuplicatesAndUnknownDependencies(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Register("a", &BaseSystem{}); err != nil {
+		t.Fatalf("Register returned an error: %v", err)
+	}
+	if err := r.Register("a", &BaseSystem{}); err == nil {
+		t.Error("Expected duplicate registration to fail")
+	}
+
+	r.Register("b", &BaseSystem{}, RunsAfter("missing"))
+	if _, err := r.Sort(); err == nil {
+		t.Error("Expected unknown dependency error")
+	}
+}
+
+func TestRegistryLookup(t *testing.T) {
+	r := NewRegistry()
+	base := &BaseSystem{}
+	other := &otherSystem{}
+	r.Register("base", base)
+	r.Register("other", other)
+
+	if sys, ok := r.Get("other"); !ok || sys != other {
+		t.Error("Get should return the system registered under the name")
+	}
+	if _, ok := r.Get("missing"); ok {
+		t.Error("Get should fail for unknown names")
+	}
+	if sys, ok := Lookup[*otherSystem](r); !ok || sys != other {
+		t.Error("Lookup should find the system by type")
+	}
+}
@@ -0,0 +1,81 @@
+package core
+
+import "fmt"
+
+// SystemError est renvoyée par le Scheduler quand un système échoue pendant un tick
+type SystemError struct {
+	Tick   uint64
+	Index  int
+	Name   string
+	System System
+	Err    error
+}
+
+func (e *SystemError) Error() string {
+	return fmt.Sprintf("system %d %s (%T) failed at tick %d: %v", e.Index, e.Name, e.System, e.Tick, e.Err)
+}
+
+func (e *SystemError) Unwrap() error {
+	return e.Err
+}
+
+// Scheduler exécute chaque système exactement une fois par tick, dans l'ordre d'enregistrement
+type Scheduler struct {
+	names   []string
+	systems []System
+	filter  func(name string) bool
+	tick    uint64
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		names:   make([]string, 0),
+		systems: make([]System, 0),
+	}
+}
+
+func (s *Scheduler) Register(name string, system System) {
+	s.names = append(s.names, name)
+	s.systems = append(s.systems, system)
+}
+
+// NewSchedulerFromRegistry crée un Scheduler suivant l'ordre topologique du Registry
+func NewSchedulerFromRegistry(r *Registry) (*Scheduler, error) {
+	order, err := r.Sort()
+	if err != nil {
+		return nil, err
+	}
+	s := NewScheduler()
+	for _, name := range order {
+		sys, _ := r.Get(name)
+		s.Register(name, sys)
+	}
+	return s, nil
+}
+
+func (s *Scheduler) Systems() []System {
+	return append([]System{}, s.systems...)
+}
+
+// SetFilter restreint les systèmes exécutés à chaque tick; nil les exécute tous
+func (s *Scheduler) SetFilter(filter func(name string) bool) {
+	s.filter = filter
+}
+
+func (s *Scheduler) CurrentTick() uint64 {
+	return s.tick
+}
+
+// Tick avance d'un pas fixe; le premier système en erreur interrompt le tick
+func (s *Scheduler) Tick(deltaTime float64) error {
+	s.tick++
+	for i, sys := range s.systems {
+		if s.filter != nil && !s.filter(s.names[i]) {
+			continue
+		}
+		if err := sys.Update(deltaTime); err != nil {
+			return &SystemError{Tick: s.tick, Index: i, Name: s.names[i], System: sys, Err: err}
+		}
+	}
+	return nil
+}
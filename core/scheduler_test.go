@@ -0,0 +1,93 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+type recordingSystem struct {
+	BaseSystem
+	name  string
+	calls *[]string
+	err   error
+}
+
+func (r *recordingSystem) Update(deltaTime float64) error {
+	*r.calls = append(*r.calls, r.name)
+	return r.err
+}
+
+func TestSchedulerTickRunsSystemsInOrder(t *testing.T) {
+	var calls []string
+	s := NewScheduler()
+	s.Register("a", &recordingSystem{name: "a", calls: &calls})
+	s.Register("b", &recordingSystem{name: "b", calls: &calls})
+	s.Register("c", &recordingSystem{name: "c", calls: &calls})
+
+	for i := 0; i < 3; i++ {
+		if err := s.Tick(FixedDeltaTime); err != nil {
+			t.Fatalf("Tick returned an error: %v", err)
+		}
+	}
+
+	expected := []string{"a", "b", "c", "a", "b", "c", "a", "b", "c"}
+	if len(calls) != len(expected) {
+		t.Fatalf("Expected %d updates, got %d", len(expected), len(calls))
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Errorf("Update %d: got %s, want %s", i, calls[i], expected[i])
+		}
+	}
+	if s.CurrentTick() != 3 {
+		t.Errorf("Expected tick 3, got %d", s.CurrentTick())
+	}
+}
+
+func TestSchedulerTickStopsOnError(t *testing.T) {
+	var calls []string
+	failure := errors.New("boom")
+	s := NewScheduler()
+	s.Register("a", &recordingSystem{name: "a", calls: &calls})
+	s.Register("b", &recordingSystem{name: "b", calls: &calls, err: failure})
+	s.Register("c", &recordingSystem{name: "c", calls: &calls})
+
+	err := s.Tick(FixedDeltaTime)
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected wrapped failure, got %v", err)
+	}
+
+	var sysErr *SystemError
+	if !errors.As(err, &sysErr) {
+		t.Fatalf("Expected *SystemError, got %T", err)
+	}
+	if sysErr.Index != 1 || sysErr.Tick != 1 {
+		t.Errorf("Unexpected error details: index %d, tick %d", sysErr.Index, sysErr.Tick)
+	}
+	if len(calls) != 2 {
+		t.Errorf("Expected 2 updates before failure, got %d", len(calls))
+	}
+}
+
+func TestSchedulerFilterSkipsSystems(t *testing.T) {
+	var calls []string
+	s := NewScheduler()
+	s.Register("a", &recordingSystem{name: "a", calls: &calls})
+	s.Register("b", &recordingSystem{name: "b", calls: &calls})
+	s.Register("c", &recordingSystem{name: "c", calls: &calls})
+
+	s.SetFilter(func(name string) bool { return name != "b" })
+	s.Tick(FixedDeltaTime)
+	s.SetFilter(nil)
+	s.Tick(FixedDeltaTime)
+
+	expected := []string{"a", "c", "a", "b", "c"}
+	if len(calls) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, calls)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Errorf("Update %d: got %s, want %s", i, calls[i], expected[i])
+		}
+	}
+}
@@ -5,8 +5,8 @@ import (
 
 	"github.com/ajkula/shmup/common"
 	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/topics"
 	"github.com/ajkula/shmup/types"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type Boss struct {
@@ -15,20 +15,25 @@ type Boss struct {
 	eventManager  interfaces.EventManagerInterface
 	ShootCooldown float64
 	maxCooldown   float64
+	// la phase 2 commence à mi-vie
+	phaseTwoAt int
 }
 
-func NewBoss(position types.Vector2D, eventManager interfaces.EventManagerInterface) *Boss {
+func NewBoss(position types.Vector2D, eventManager interfaces.EventManagerInterface, opts ...Option) *Boss {
+	preset := newOptions(opts).preset()
+	health := scaleHealth(1000, preset.EnemyHealth)
 	return &Boss{
 		BaseEntity: types.BaseEntity{
 			Position: position,
 			Width:    64, Height: 64,
 			Speed:  1,
-			Health: 1000,
+			Health: health,
 		},
 		phase:         1,
 		eventManager:  eventManager,
 		ShootCooldown: 0,
-		maxCooldown:   0.2,
+		maxCooldown:   0.2 * preset.FireCooldown,
+		phaseTwoAt:    health / 2,
 	}
 }
 
@@ -37,13 +42,13 @@ func (b *Boss) Update(deltaTime float64) error {
 	if b.CanShoot() {
 		b.Shoot()
 	}
-	if b.Health <= 500 && b.phase == 1 {
+	if b.Health <= b.phaseTwoAt && b.phase == 1 {
 		b.ChangePhase(2)
 	}
 	return nil
 }
 
-func (b *Boss) Draw(screen *ebiten.Image) {
+func (b *Boss) Draw(screen types.Screen) {
 	// todo
 }
 
@@ -60,9 +65,9 @@ func (b *Boss) CanCollideWith(other types.Entity) bool {
 
 func (b *Boss) OnCollision(other types.Entity) {
 	b.TakeDamage(10)
-	b.eventManager.Publish(interfaces.BossDamaged, b)
+	topics.BossDamaged.Publish(b.eventManager, b)
 	if b.Health <= 0 {
-		b.eventManager.Publish(interfaces.BossDefeated, b)
+		topics.BossDefeated.Publish(b.eventManager, b)
 	}
 }
 
@@ -71,13 +76,13 @@ func (b *Boss) CanShoot() bool {
 }
 
 func (b *Boss) Shoot() {
-	b.eventManager.Publish(interfaces.BossShot, b)
+	topics.BossShot.Publish(b.eventManager, b)
 	b.ShootCooldown = b.maxCooldown
 }
 
 func (b *Boss) ChangePhase(newPhase int) {
 	b.phase = newPhase
-	b.eventManager.Publish(interfaces.BossPhaseChanged, b)
+	topics.BossPhaseChanged.Publish(b.eventManager, b)
 }
 
 var _ types.GameEntity = (*Boss)(nil)
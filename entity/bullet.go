@@ -3,8 +3,8 @@ package entity
 import (
 	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/topics"
 	"github.com/ajkula/shmup/types"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type Bullet struct {
@@ -12,30 +12,41 @@ type Bullet struct {
 	isEnemy      bool
 	eventManager interfaces.EventManagerInterface
 	direction    types.Vector2D
+	// terrain au-delà duquel la balle est détruite
+	bounds types.Vector2D
+	// multiplicateur de difficulté, conservé pour les rechargements de config
+	speedScale float64
 }
 
-func NewBullet(x, y float64, isEnemy bool, eventManager interfaces.EventManagerInterface) *Bullet {
+func NewBullet(x, y float64, isEnemy bool, eventManager interfaces.EventManagerInterface, opts ...Option) *Bullet {
+	o := newOptions(opts)
 	direction := types.Vector2D{X: 0, Y: -1}
+	speedScale := 1.0
 	if isEnemy {
 		direction.Y = 1
+		speedScale = o.preset().BulletSpeed
 	}
 
 	return &Bullet{
 		BaseEntity: types.BaseEntity{
 			Position: types.Vector2D{X: x, Y: y},
 			Width:    8, Height: 8,
-			Speed:  10,
+			Speed:  o.cfg.BulletSpeed * speedScale,
 			Health: 1,
 		},
 		isEnemy:      isEnemy,
 		eventManager: eventManager,
 		direction:    direction,
+		bounds:       types.Vector2D{X: float64(o.cfg.ScreenWidth), Y: float64(o.cfg.ScreenHeight)},
+		speedScale:   speedScale,
 	}
 }
 
+// Update avance la balle de Speed pixels par tick, l'unité de BulletSpeed,
+// comme le joueur avance de PlayerSpeed
 func (b *Bullet) Update(deltaTime float64) error {
 	newPos := b.GetPosition()
-	newPos = newPos.Add(b.direction.Multiply(b.Speed * deltaTime))
+	newPos = newPos.Add(b.direction.Multiply(b.Speed))
 	b.SetPosition(newPos)
 
 	if b.IsOutOfBounds() {
@@ -48,26 +59,30 @@ func (b *Bullet) Update(deltaTime float64) error {
 func (b *Bullet) Destroy() {
 	if b.IsAlive() {
 		b.Health = 0
-		b.eventManager.Publish(interfaces.BulletDestroyed, b)
+		topics.BulletDestroyed.Publish(b.eventManager, b)
 	}
 }
 
 func (b *Bullet) IsOutOfBounds() bool {
 	pos := b.GetPosition()
-	return pos.X < 0 || pos.X > float64(config.Config.ScreenWidth) || pos.Y < 0 || pos.Y > float64(config.Config.ScreenHeight)
+	return pos.X < 0 || pos.X > b.bounds.X || pos.Y < 0 || pos.Y > b.bounds.Y
 }
 
-func (b *Bullet) Draw(screen *ebiten.Image) {
+func (b *Bullet) Draw(screen types.Screen) {
 	// TODO
 }
 
+// CanCollideWith: les balles ennemies ne touchent que le joueur, celles du
+// joueur que les ennemis et les boss, jamais d'autres balles
 func (b *Bullet) CanCollideWith(other types.Entity) bool {
-	if b.isEnemy {
-		_, isPlayer := other.(types.GameEntity)
-		return isPlayer
+	switch other.(type) {
+	case *Player:
+		return b.isEnemy
+	case *Enemy, *Boss:
+		return !b.isEnemy
+	default:
+		return false
 	}
-	_, isEnemy := other.(types.GameEntity)
-	return isEnemy
 }
 
 func (b *Bullet) OnCollision(other types.Entity) {
@@ -78,4 +93,12 @@ func (b *Bullet) IsEnemyBullet() bool {
 	return b.isEnemy
 }
 
-var _ types.GameEntity = (*Bullet)(nil)
+// ApplyConfig reprend la vitesse rechargée depuis la config
+func (b *Bullet) ApplyConfig(cfg config.GameConfig) {
+	b.Speed = cfg.BulletSpeed * b.speedScale
+}
+
+var (
+	_ types.GameEntity   = (*Bullet)(nil)
+	_ types.Configurable = (*Bullet)(nil)
+)
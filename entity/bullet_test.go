@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/mocks"
+	"github.com/ajkula/shmup/types"
+)
+
+func TestBulletBoundsFromInjectedConfig(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	small := config.Default()
+	small.ScreenWidth, small.ScreenHeight = 200, 200
+	large := config.Default()
+	large.ScreenWidth, large.ScreenHeight = 800, 800
+
+	inSmall := NewBullet(400, 400, false, eventManager, WithConfig(small))
+	inLarge := NewBullet(400, 400, false, eventManager, WithConfig(large))
+
+	if !inSmall.IsOutOfBounds() {
+		t.Error("Bullet at (400,400) should be out of a 200x200 playfield")
+	}
+	if inLarge.IsOutOfBounds() {
+		t.Error("Bullet at (400,400) should be inside an 800x800 playfield")
+	}
+	if inLarge.Speed != large.BulletSpeed {
+		t.Errorf("Bullet speed: got %v, want %v", inLarge.Speed, large.BulletSpeed)
+	}
+}
+
+func TestBulletMovesSpeedPixelsPerTick(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	cfg := config.Default()
+	bullet := NewBullet(100, 500, false, eventManager, WithConfig(cfg))
+
+	for i := 0; i < 3; i++ {
+		bullet.Update(1.0 / 60.0)
+	}
+	if y := bullet.GetPosition().Y; y != 500-3*cfg.BulletSpeed {
+		t.Errorf("Bullet should move %v pixels per tick, got y=%v after 3 ticks", cfg.BulletSpeed, y)
+	}
+}
+
+func TestBulletCanCollideWith(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	player := NewPlayer(types.Vector2D{}, eventManager)
+	enemy := NewEnemy(types.Vector2D{}, eventManager)
+	boss := NewBoss(types.Vector2D{}, eventManager)
+	playerBullet := NewBullet(0, 0, false, eventManager)
+	enemyBullet := NewBullet(0, 0, true, eventManager)
+
+	if !playerBullet.CanCollideWith(enemy) || !playerBullet.CanCollideWith(boss) {
+		t.Error("Player bullets should hit enemies and bosses")
+	}
+	if playerBullet.CanCollideWith(player) || playerBullet.CanCollideWith(NewBullet(0, 0, false, eventManager)) {
+		t.Error("Player bullets should not hit the player or other player bullets")
+	}
+	if !enemyBullet.CanCollideWith(player) {
+		t.Error("Enemy bullets should hit the player")
+	}
+	if enemyBullet.CanCollideWith(enemy) || enemyBullet.CanCollideWith(playerBullet) {
+		t.Error("Enemy bullets should not hit enemies or other bullets")
+	}
+}
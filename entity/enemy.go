@@ -4,9 +4,10 @@ import (
 	"math"
 
 	"github.com/ajkula/shmup/common"
+	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/topics"
 	"github.com/ajkula/shmup/types"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type Enemy struct {
@@ -16,16 +17,18 @@ type Enemy struct {
 	eventManager  interfaces.EventManagerInterface
 }
 
-func NewEnemy(position types.Vector2D, eventManager interfaces.EventManagerInterface) *Enemy {
+func NewEnemy(position types.Vector2D, eventManager interfaces.EventManagerInterface, opts ...Option) *Enemy {
+	o := newOptions(opts)
+	preset := o.preset()
 	return &Enemy{
 		BaseEntity: types.BaseEntity{
 			Position: position,
 			Width:    32, Height: 32,
-			Speed:  2,
-			Health: 20,
+			Speed:  o.cfg.EnemySpeed,
+			Health: scaleHealth(20, preset.EnemyHealth),
 		},
 		shootCooldown: 0,
-		maxCooldown:   1.0,
+		maxCooldown:   1.0 * preset.FireCooldown,
 		eventManager:  eventManager,
 	}
 }
@@ -38,7 +41,7 @@ func (e *Enemy) Update(deltaTime float64) error {
 	return nil
 }
 
-func (e *Enemy) Draw(screen *ebiten.Image) {
+func (e *Enemy) Draw(screen types.Screen) {
 	// todo
 }
 
@@ -55,9 +58,9 @@ func (e *Enemy) CanCollideWith(other types.Entity) bool {
 
 func (e *Enemy) OnCollision(other types.Entity) {
 	e.TakeDamage(10)
-	e.eventManager.Publish(interfaces.EnemyDamaged, e)
+	topics.EnemyDamaged.Publish(e.eventManager, e)
 	if e.Health <= 0 {
-		e.eventManager.Publish(interfaces.EnemyDestroyed, e)
+		topics.EnemyDestroyed.Publish(e.eventManager, e)
 	}
 }
 
@@ -66,8 +69,16 @@ func (e *Enemy) CanShoot() bool {
 }
 
 func (e *Enemy) Shoot() {
-	e.eventManager.Publish(interfaces.EnemyShot, e)
+	topics.EnemyShot.Publish(e.eventManager, e)
 	e.shootCooldown = e.maxCooldown
 }
 
-var _ types.GameEntity = (*Enemy)(nil)
+// ApplyConfig reprend la vitesse rechargée depuis la config
+func (e *Enemy) ApplyConfig(cfg config.GameConfig) {
+	e.Speed = cfg.EnemySpeed
+}
+
+var (
+	_ types.GameEntity   = (*Enemy)(nil)
+	_ types.Configurable = (*Enemy)(nil)
+)
@@ -2,8 +2,8 @@ package entity
 
 import (
 	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/topics"
 	"github.com/ajkula/shmup/types"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type Formation struct {
@@ -40,7 +40,7 @@ func (f *Formation) Update(deltaTime float64) error {
 	return nil
 }
 
-func (f *Formation) Draw(screen *ebiten.Image) {
+func (f *Formation) Draw(screen types.Screen) {
 	for _, enemy := range f.enemies {
 		enemy.Draw(screen)
 	}
@@ -57,7 +57,7 @@ func (f *Formation) GetEntities() []types.Entity {
 func (f *Formation) AddEntity(e types.Entity) {
 	if enemy, ok := e.(types.GameEntity); ok {
 		f.enemies = append(f.enemies, enemy)
-		f.eventManager.Publish(interfaces.EnemyAddedToFormation, enemy)
+		topics.EnemyAddedToFormation.Publish(f.eventManager, enemy)
 	}
 }
 
@@ -65,7 +65,7 @@ func (f *Formation) RemoveEntity(e types.Entity) {
 	for i, enemy := range f.enemies {
 		if enemy == e {
 			f.enemies = append(f.enemies[:i], f.enemies[i+1:]...)
-			f.eventManager.Publish(interfaces.EnemyRemovedFromFormation, enemy)
+			topics.EnemyRemovedFromFormation.Publish(f.eventManager, enemy)
 			break
 		}
 	}
@@ -90,7 +90,7 @@ func (f *Formation) GetPattern() types.MovementPattern {
 func (f *Formation) checkCompletion() {
 	if !f.IsComplete() && len(f.enemies) == 0 {
 		f.complete = true
-		f.eventManager.Publish(interfaces.FormationDestroyed, f)
+		topics.FormationDestroyed.Publish(f.eventManager, f)
 	}
 }
 
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"math"
+
+	"github.com/ajkula/shmup/config"
+)
+
+// Option personnalise une entité à sa création
+type Option func(o *options)
+
+type options struct {
+	cfg        config.GameConfig
+	difficulty *config.DifficultyPreset
+}
+
+// WithConfig remplace le global config.Config pour cette entité
+func WithConfig(cfg config.GameConfig) Option {
+	return func(o *options) {
+		o.cfg = cfg
+	}
+}
+
+// WithDifficulty impose un preset de difficulté, sinon celui de la config est utilisé.
+// En partie, EnemyManager et BulletManager passent LevelManager.Scaling(): le
+// preset choisi au menu, durci par le niveau et le rang.
+func WithDifficulty(preset config.DifficultyPreset) Option {
+	return func(o *options) {
+		o.difficulty = &preset
+	}
+}
+
+func (o options) preset() config.DifficultyPreset {
+	if o.difficulty != nil {
+		return *o.difficulty
+	}
+	return o.cfg.DifficultyPreset()
+}
+
+func scaleHealth(health int, factor float64) int {
+	return max(1, int(math.Round(float64(health)*factor)))
+}
+
+func newOptions(opts []Option) options {
+	o := options{cfg: config.Config}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
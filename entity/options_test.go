@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/mocks"
+	"github.com/ajkula/shmup/types"
+)
+
+func TestDifficultyScalesNewEntities(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	lunatic, _ := config.LookupDifficulty("Lunatic")
+	cfg := config.Default()
+
+	enemy := NewEnemy(types.Vector2D{}, eventManager, WithConfig(cfg), WithDifficulty(lunatic))
+	if enemy.Health != 40 {
+		t.Errorf("Lunatic enemy health: got %d, want 40", enemy.Health)
+	}
+	if enemy.maxCooldown != 0.5 {
+		t.Errorf("Lunatic enemy maxCooldown: got %v, want 0.5", enemy.maxCooldown)
+	}
+
+	boss := NewBoss(types.Vector2D{}, eventManager, WithDifficulty(lunatic))
+	if boss.Health != 2000 || boss.maxCooldown != 0.1 {
+		t.Errorf("Lunatic boss: got health %d and maxCooldown %v", boss.Health, boss.maxCooldown)
+	}
+
+	enemyBullet := NewBullet(0, 0, true, eventManager, WithConfig(cfg), WithDifficulty(lunatic))
+	if want := cfg.BulletSpeed * lunatic.BulletSpeed; enemyBullet.Speed != want {
+		t.Errorf("Lunatic enemy bullet speed: got %v, want %v", enemyBullet.Speed, want)
+	}
+	playerBullet := NewBullet(0, 0, false, eventManager, WithConfig(cfg), WithDifficulty(lunatic))
+	if playerBullet.Speed != cfg.BulletSpeed {
+		t.Errorf("Player bullets should not be scaled: got %v", playerBullet.Speed)
+	}
+
+	reloaded := cfg
+	reloaded.BulletSpeed = 4
+	enemyBullet.ApplyConfig(reloaded)
+	if want := 4 * lunatic.BulletSpeed; enemyBullet.Speed != want {
+		t.Errorf("Reloaded enemy bullet speed: got %v, want %v", enemyBullet.Speed, want)
+	}
+}
+
+func TestDifficultyFromConfig(t *testing.T) {
+	cfg := config.Default()
+	cfg.Difficulty = "easy"
+
+	enemy := NewEnemy(types.Vector2D{}, mocks.NewMockEventManager(), WithConfig(cfg))
+	if enemy.Health != 15 {
+		t.Errorf("Easy enemy health: got %d, want 15", enemy.Health)
+	}
+}
@@ -1,36 +1,175 @@
 package entity
 
 import (
+	"math"
+
+	"github.com/ajkula/shmup/common"
+	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/topics"
 	"github.com/ajkula/shmup/types"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
+const PlayerMaxHealth = 100
+
+// FocusSpeedFactor ralentit le joueur tant que la touche focus est maintenue
+const FocusSpeedFactor = 0.5
+
 type Player struct {
 	types.BaseEntity
 	ShootCooldown float64
 	eventManager  interfaces.EventManagerInterface
+	// direction demandée pendant ce tick, remise à zéro après chaque Update
+	steering types.Vector2D
+	focused  bool
+	// terrain dans lequel le joueur reste confiné
+	bounds types.Vector2D
+
+	weapon Weapon
+	// gâchette pendant ce tick, remise à zéro après chaque Update
+	triggerPressed bool
+	triggerHeld    bool
+	// tir continu sans maintenir la gâchette
+	autofire bool
+	charging bool
+	charge   float64
 }
 
-func NewPlayer(position types.Vector2D, eventManager interfaces.EventManagerInterface) *Player {
+func NewPlayer(position types.Vector2D, eventManager interfaces.EventManagerInterface, opts ...Option) *Player {
+	o := newOptions(opts)
 	return &Player{
 		BaseEntity: types.BaseEntity{
 			Position: position,
 			Width:    32, Height: 32,
-			Speed:  5,
-			Health: 100,
+			Speed:  o.cfg.PlayerSpeed,
+			Health: PlayerMaxHealth,
 		},
 		ShootCooldown: 0,
 		eventManager:  eventManager,
+		bounds:        types.Vector2D{X: float64(o.cfg.ScreenWidth), Y: float64(o.cfg.ScreenHeight)},
+		weapon:        Blaster,
+		autofire:      o.cfg.Autofire,
 	}
 }
 
+// Update est appelé une fois par tick fixe: le déplacement demandé via Steer
+// y est appliqué à raison de Speed pixels par tick, puis la gâchette est lue
 func (p *Player) Update(deltaTime float64) error {
 	p.ShootCooldown -= deltaTime
+	p.move()
+	p.fire(deltaTime)
 	return nil
 }
 
-func (p *Player) Draw(screen *ebiten.Image) {
+// Steer ajoute une direction maintenue pour le prochain Update
+func (p *Player) Steer(direction types.Vector2D) {
+	p.steering = p.steering.Add(direction)
+}
+
+// Focus ralentit le prochain déplacement
+func (p *Player) Focus() {
+	p.focused = true
+}
+
+// PressTrigger signale un nouvel appui sur la gâchette pour le prochain Update
+func (p *Player) PressTrigger() {
+	p.triggerPressed = true
+}
+
+// HoldTrigger signale la gâchette maintenue pour le prochain Update
+func (p *Player) HoldTrigger() {
+	p.triggerHeld = true
+}
+
+// Equip change d'arme; une charge en cours est perdue
+func (p *Player) Equip(weapon Weapon) {
+	p.weapon = weapon
+	p.charging, p.charge = false, 0
+}
+
+func (p *Player) Weapon() Weapon {
+	return p.weapon
+}
+
+func (p *Player) SetAutofire(on bool) {
+	p.autofire = on
+}
+
+func (p *Player) Autofire() bool {
+	return p.autofire
+}
+
+func (p *Player) fire(deltaTime float64) {
+	pressed := p.triggerPressed
+	held := p.triggerHeld || pressed
+	p.triggerPressed, p.triggerHeld = false, false
+
+	if !p.weapon.Charges() {
+		if held || p.autofire {
+			p.Shoot()
+		}
+		return
+	}
+
+	switch {
+	case pressed:
+		// un appui bref tire un coup normal, le maintien charge
+		p.Shoot()
+		p.charging, p.charge = true, 0
+	case held && p.charging:
+		p.charge += deltaTime
+	case p.charging:
+		if p.charge >= p.weapon.ChargeTime-common.Epsilon {
+			p.chargedShot()
+		}
+		p.charging, p.charge = false, 0
+	case p.autofire:
+		p.Shoot()
+	}
+}
+
+// chargedShot ignore le cooldown: la charge a déjà coûté ChargeTime
+func (p *Player) chargedShot() {
+	topics.PlayerShot.Publish(p.eventManager, p)
+	ChargedShotTopic.Publish(p.eventManager, ChargedShot{Shooter: p, Weapon: p.weapon.Name, Charge: p.charge})
+	p.ShootCooldown = p.weapon.Cooldown
+}
+
+// Charge renvoie la progression de la charge en cours, dans [0,1]
+func (p *Player) Charge() float64 {
+	if !p.charging || !p.weapon.Charges() {
+		return 0
+	}
+	return math.Min(1, p.charge/p.weapon.ChargeTime)
+}
+
+func (p *Player) move() {
+	direction := p.steering.Normalize()
+	speed := p.Speed
+	if p.focused {
+		speed *= FocusSpeedFactor
+	}
+	p.steering, p.focused = types.Vector2D{}, false
+	if direction == (types.Vector2D{}) {
+		return
+	}
+
+	pos := p.Position.Add(direction.Multiply(speed))
+	pos.X = math.Max(0, math.Min(pos.X, p.bounds.X-p.Width))
+	pos.Y = math.Max(0, math.Min(pos.Y, p.bounds.Y-p.Height))
+	p.Position = pos
+}
+
+// Revive rend toute sa vie au joueur, après un continue ou pour une nouvelle partie
+func (p *Player) Revive() {
+	p.Health = PlayerMaxHealth
+	p.ShootCooldown = 0
+	p.steering, p.focused = types.Vector2D{}, false
+	p.triggerPressed, p.triggerHeld = false, false
+	p.charging, p.charge = false, 0
+}
+
+func (p *Player) Draw(screen types.Screen) {
 	// TODO
 }
 
@@ -49,21 +188,32 @@ func (p *Player) CanCollideWith(other types.Entity) bool {
 
 func (p *Player) OnCollision(other types.Entity) {
 	p.TakeDamage(10)
-	p.eventManager.Publish(interfaces.PlayerDamaged, p)
+	topics.PlayerDamaged.Publish(p.eventManager, p)
 	if p.Health <= 0 {
-		p.eventManager.Publish(interfaces.PlayerDestroyed, p)
+		topics.PlayerDestroyed.Publish(p.eventManager, p)
 	}
 }
 
+// la tolérance évite de perdre un tick quand la somme des deltaTime
+// n'atteint pas exactement le cooldown
 func (p *Player) CanShoot() bool {
-	return p.ShootCooldown <= 0
+	return p.ShootCooldown <= common.Epsilon
 }
 
 func (p *Player) Shoot() {
 	if p.CanShoot() {
-		p.eventManager.Publish(interfaces.PlayerShot, p)
-		p.ShootCooldown = 0.2
+		topics.PlayerShot.Publish(p.eventManager, p)
+		p.ShootCooldown = p.weapon.Cooldown
 	}
 }
 
-var _ types.GameEntity = (*Player)(nil)
+// ApplyConfig reprend la vitesse et l'autofire rechargés depuis la config
+func (p *Player) ApplyConfig(cfg config.GameConfig) {
+	p.Speed = cfg.PlayerSpeed
+	p.autofire = cfg.Autofire
+}
+
+var (
+	_ types.GameEntity   = (*Player)(nil)
+	_ types.Configurable = (*Player)(nil)
+)
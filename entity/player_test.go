@@ -2,8 +2,10 @@ package entity
 
 import (
 	"context"
+	"math"
 	"testing"
 
+	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/interfaces"
 	"github.com/ajkula/shmup/mocks"
 	"github.com/ajkula/shmup/types"
@@ -161,3 +163,171 @@ func TestPlayerCanShoot(t *testing.T) {
 		t.Error("Player should be able to shoot after cooldown")
 	}
 }
+
+func TestPlayerRevive(t *testing.T) {
+	player := NewPlayer(types.Vector2D{X: 100, Y: 100}, mocks.NewMockEventManager())
+	player.TakeDamage(PlayerMaxHealth)
+	player.ShootCooldown = 0.2
+
+	player.Revive()
+
+	if player.GetHealth() != PlayerMaxHealth {
+		t.Errorf("Player health after Revive: got %v, want %v", player.GetHealth(), PlayerMaxHealth)
+	}
+	if !player.CanShoot() {
+		t.Error("Player should be able to shoot after Revive")
+	}
+}
+
+func TestPlayerHeldMovement(t *testing.T) {
+	player := NewPlayer(types.Vector2D{X: 100, Y: 100}, mocks.NewMockEventManager())
+
+	player.Steer(types.Vector2D{Y: -1})
+	player.Update(0.016)
+	if pos := player.GetPosition(); pos.X != 100 || pos.Y != 100-player.Speed {
+		t.Errorf("Position after moving up: got %+v", pos)
+	}
+
+	player.Update(0.016)
+	if pos := player.GetPosition(); pos.Y != 100-player.Speed {
+		t.Errorf("Player should stop once the key is released, got %+v", pos)
+	}
+
+	start := player.GetPosition()
+	player.Steer(types.Vector2D{X: 1})
+	player.Steer(types.Vector2D{Y: 1})
+	player.Update(0.016)
+	if moved := player.GetPosition().Subtract(start).Length(); math.Abs(moved-player.Speed) > 1e-9 {
+		t.Errorf("Diagonal movement should be normalized: moved %v, want %v", moved, player.Speed)
+	}
+
+	start = player.GetPosition()
+	player.Steer(types.Vector2D{X: -1})
+	player.Focus()
+	player.Update(0.016)
+	if moved := start.X - player.GetPosition().X; math.Abs(moved-player.Speed*FocusSpeedFactor) > 1e-9 {
+		t.Errorf("Focused movement: moved %v, want %v", moved, player.Speed*FocusSpeedFactor)
+	}
+}
+
+func TestPlayerMovementClampedToPlayfield(t *testing.T) {
+	cfg := config.Default()
+	cfg.ScreenWidth, cfg.ScreenHeight = 200, 300
+	player := NewPlayer(types.Vector2D{X: 2, Y: 290 - 32}, mocks.NewMockEventManager(), WithConfig(cfg))
+
+	for i := 0; i < 10; i++ {
+		player.Steer(types.Vector2D{X: -1, Y: 1})
+		player.Update(0.016)
+	}
+
+	if pos := player.GetPosition(); pos.X != 0 || pos.Y != 300-32 {
+		t.Errorf("Player should stop at the bottom-left corner, got %+v", pos)
+	}
+}
+
+// countShots fait tourner le joueur ticks fois à 60 ticks par seconde
+func countShots(t *testing.T, player *Player, eventManager *mocks.MockEventManager, ticks int, trigger func(tick int)) int {
+	t.Helper()
+	shots, err := eventManager.Subscribe(interfaces.PlayerShot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for tick := 0; tick < ticks; tick++ {
+		trigger(tick)
+		player.Update(1.0 / 60)
+	}
+	eventManager.Update(0)
+	return len(shots)
+}
+
+func TestPlayerHeldFireUsesWeaponRate(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	eventManager.Initialize(context.Background())
+	player := NewPlayer(types.Vector2D{X: 100, Y: 100}, eventManager)
+
+	got := countShots(t, player, eventManager, 60, func(tick int) {
+		if tick == 0 {
+			player.PressTrigger()
+		}
+		player.HoldTrigger()
+	})
+	// 0.2s de cooldown: un tir toutes les 12 ticks pendant une seconde
+	if got != 5 {
+		t.Errorf("shots while holding fire for 1s: got %d, want 5", got)
+	}
+
+	player.Equip(Weapon{Name: "Vulcan", Cooldown: 0.1})
+	player.ShootCooldown = 0
+	got = countShots(t, player, eventManager, 60, func(int) { player.HoldTrigger() })
+	if got != 10 {
+		t.Errorf("shots with a 0.1s weapon: got %d, want 10", got)
+	}
+}
+
+func TestPlayerAutofire(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	eventManager.Initialize(context.Background())
+	cfg := config.Default()
+	cfg.Autofire = true
+	player := NewPlayer(types.Vector2D{X: 100, Y: 100}, eventManager, WithConfig(cfg))
+
+	if got := countShots(t, player, eventManager, 60, func(int) {}); got != 5 {
+		t.Errorf("shots with autofire for 1s: got %d, want 5", got)
+	}
+
+	cfg.Autofire = false
+	player.ApplyConfig(cfg)
+	if got := countShots(t, player, eventManager, 60, func(int) {}); got != 0 {
+		t.Errorf("shots after disabling autofire: got %d, want 0", got)
+	}
+}
+
+func TestPlayerChargeWeapon(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	eventManager.Initialize(context.Background())
+	player := NewPlayer(types.Vector2D{X: 100, Y: 100}, eventManager)
+	player.Equip(Charger)
+	charged, _ := eventManager.Subscribe(PlayerChargedShot)
+
+	// appui bref: un coup normal, pas de charge
+	got := countShots(t, player, eventManager, 30, func(tick int) {
+		if tick == 0 {
+			player.PressTrigger()
+		}
+	})
+	if got != 1 || len(charged) != 0 {
+		t.Errorf("tap: got %d shots and %d charged, want 1 and 0", got, len(charged))
+	}
+
+	// maintien d'une seconde puis relâchement: coup normal puis coup chargé
+	got = countShots(t, player, eventManager, 61, func(tick int) {
+		switch {
+		case tick == 0:
+			player.PressTrigger()
+		case tick < 60:
+			player.HoldTrigger()
+		}
+		if tick == 59 && player.Charge() != 1 {
+			t.Errorf("charge before release: got %v, want 1", player.Charge())
+		}
+	})
+	if got != 2 || len(charged) != 1 {
+		t.Fatalf("hold: got %d shots and %d charged, want 2 and 1", got, len(charged))
+	}
+	if shot := (<-charged).Data.(ChargedShot); shot.Weapon != Charger.Name || shot.Charge < Charger.ChargeTime {
+		t.Errorf("charged shot payload: got %+v", shot)
+	}
+
+	// relâché avant ChargeTime: pas de coup chargé
+	countShots(t, player, eventManager, 30, func(tick int) {
+		if tick == 0 {
+			player.PressTrigger()
+		} else if tick < 20 {
+			player.HoldTrigger()
+		}
+	})
+	eventManager.Update(0)
+	if len(charged) != 0 {
+		t.Error("releasing before ChargeTime should not fire a charged shot")
+	}
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"github.com/ajkula/shmup/event"
+	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/types"
+)
+
+// Weapon fixe la cadence de tir du joueur. Une arme à charge (ChargeTime > 0)
+// tire un coup normal à l'appui et un coup chargé au relâchement si la
+// gâchette a été maintenue au moins ChargeTime secondes.
+type Weapon struct {
+	Name       string
+	Cooldown   float64
+	ChargeTime float64
+}
+
+var (
+	Blaster = Weapon{Name: "Blaster", Cooldown: 0.2}
+	Charger = Weapon{Name: "Charger", Cooldown: 0.3, ChargeTime: 0.8}
+)
+
+func (w Weapon) Charges() bool {
+	return w.ChargeTime > 0
+}
+
+// ChargedShot accompagne le PlayerShot d'un coup chargé
+type ChargedShot struct {
+	Shooter types.GameEntity
+	Weapon  string
+	// durée de maintien, au moins ChargeTime
+	Charge float64
+}
+
+var (
+	PlayerChargedShot = interfaces.RegisterEventType("PlayerChargedShot", "player released a charged shot")
+	ChargedShotTopic  = event.NewTopic[ChargedShot](PlayerChargedShot)
+)
@@ -3,34 +3,90 @@ package event
 import (
 	"context"
 	"fmt"
+	"log"
 	"sync"
+	"sync/atomic"
 
 	"github.com/ajkula/shmup/core"
 	"github.com/ajkula/shmup/interfaces"
 )
 
+type DispatchMode int
+
+const (
+	// DispatchAsync distribue les événements depuis une goroutine dédiée, dès leur publication
+	DispatchAsync DispatchMode = iota
+	// DispatchPerTick met les événements en file et les distribue à chaque Update
+	DispatchPerTick
+)
+
+// DefaultQueueSize est la capacité par défaut de la file des événements publiés
+// et pas encore distribués
+const DefaultQueueSize = 5000
+
+type Option func(em *EventManager)
+
+func WithDispatchMode(mode DispatchMode) Option {
+	return func(em *EventManager) {
+		em.mode = mode
+	}
+}
+
+// WithQueueSize fixe la capacité de la file: une fois pleine, Publish échoue
+// et compte l'événement comme perdu
+func WithQueueSize(size int) Option {
+	return func(em *EventManager) {
+		em.queueSize = size
+	}
+}
+
 type EventManager struct {
 	core.BaseSystem
 	eventChan   chan interfaces.Event
-	subscribers map[interfaces.EventType][]chan interfaces.Event
+	subscribers map[interfaces.EventType][]*subscriber
+	filtered    []*subscriber
+	mode        DispatchMode
+	queueSize   int
+	running     bool
 	mu          sync.RWMutex
+	stats       *statsRegistry
+	timers      *TimerQueue
+	middlewares middlewareChain
+	tick        atomic.Uint64
 }
 
-func NewEventManager() interfaces.EventManagerInterface {
-	return &EventManager{
-		eventChan:   make(chan interfaces.Event, 5000),
-		subscribers: make(map[interfaces.EventType][]chan interfaces.Event),
+func NewEventManager(opts ...Option) interfaces.EventManagerInterface {
+	em := &EventManager{
+		subscribers: make(map[interfaces.EventType][]*subscriber),
+		mode:        DispatchAsync,
+		queueSize:   DefaultQueueSize,
+		stats:       newStatsRegistry(),
+		timers:      NewTimerQueue(),
 	}
+	for _, opt := range opts {
+		opt(em)
+	}
+	em.eventChan = make(chan interfaces.Event, em.queueSize)
+	return em
 }
 
 func (em *EventManager) Initialize(ctx context.Context) error {
 	if err := em.BaseSystem.Initialize(ctx); err != nil {
 		return err
 	}
-	go em.processEvents()
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	if em.mode == DispatchAsync && !em.running {
+		em.running = true
+		go em.processEvents()
+	}
 	return nil
 }
 
+func (em *EventManager) Mode() DispatchMode {
+	return em.mode
+}
+
 func (em *EventManager) processEvents() {
 	for {
 		select {
@@ -47,58 +103,174 @@ func (em *EventManager) Update(deltaTime float64) error {
 	case <-em.CTX.Done():
 		return em.CTX.Err()
 	default:
+		em.tick.Add(1)
+		// les timers échus sont publiés avant la distribution du tick
+		// un timer n'a pas d'appelant à qui rendre l'erreur: file pleine (déjà
+		// comptée dans Stats) ou contexte annulé, on la journalise
+		for _, evt := range em.timers.Advance(deltaTime) {
+			if err := em.Publish(evt.Type, evt.Data); err != nil {
+				log.Printf("timer %v: %v", evt.Type, err)
+			}
+		}
+		if em.mode == DispatchPerTick {
+			em.Flush()
+		}
+		em.drainBacklogs()
 		return nil
 	}
 }
 
+// Flush distribue les événements publiés avant l'appel.
+// Ceux publiés pendant la distribution attendent le Flush suivant.
+func (em *EventManager) Flush() {
+	pending := len(em.eventChan)
+	for i := 0; i < pending; i++ {
+		select {
+		case event := <-em.eventChan:
+			em.dispatch(event)
+		default:
+			return
+		}
+	}
+}
+
 func (em *EventManager) Shutdown() {
 	em.mu.Lock()
 	defer em.mu.Unlock()
 	for _, subs := range em.subscribers {
-		for _, ch := range subs {
-			close(ch)
+		for _, sub := range subs {
+			close(sub.ch)
 		}
 	}
+	for _, sub := range em.filtered {
+		close(sub.ch)
+	}
 	close(em.eventChan)
 }
 
 func (em *EventManager) Publish(eventType interfaces.EventType, data interface{}) error {
+	if err := ValidatePayload(eventType, data); err != nil {
+		return err
+	}
 	select {
 	case <-em.CTX.Done():
 		return em.CTX.Err()
 	case em.eventChan <- interfaces.Event{Type: eventType, Data: data}:
+		em.stats.forType(eventType).published.Add(1)
 		return nil
 	default:
+		em.stats.forType(eventType).dropped.Add(1)
 		return fmt.Errorf("failed to publish event: %v, channel full", eventType)
 	}
 }
 
-func (em *EventManager) Subscribe(eventType interfaces.EventType) (<-chan interfaces.Event, error) {
+func (em *EventManager) Subscribe(eventType interfaces.EventType, opts ...interfaces.SubscribeOption) (<-chan interfaces.Event, error) {
 	select {
 	case <-em.CTX.Done():
 		return nil, em.CTX.Err()
 	default:
-		ch := make(chan interfaces.Event, 1000)
+		sub := newSubscriber(eventType, em.subscribeConfig(opts), em.stats)
 		em.mu.Lock()
 		defer em.mu.Unlock()
-		em.subscribers[eventType] = append(em.subscribers[eventType], ch)
-		return ch, nil
+		em.subscribers[eventType] = append(em.subscribers[eventType], sub)
+		return sub.ch, nil
 	}
 }
 
+// SubscribeAll reçoit tous les événements, tous types confondus, dans l'ordre de distribution
+func (em *EventManager) SubscribeAll(opts ...interfaces.SubscribeOption) (<-chan interfaces.Event, error) {
+	return em.SubscribeFunc(func(interfaces.Event) bool { return true }, opts...)
+}
+
+// SubscribeMany reçoit les événements des types donnés sur un seul canal ordonné
+func (em *EventManager) SubscribeMany(eventTypes []interfaces.EventType, opts ...interfaces.SubscribeOption) (<-chan interfaces.Event, error) {
+	wanted := make(map[interfaces.EventType]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		wanted[eventType] = true
+	}
+	return em.SubscribeFunc(func(evt interfaces.Event) bool { return wanted[evt.Type] }, opts...)
+}
+
+// SubscribeFunc reçoit les événements pour lesquels predicate renvoie true.
+// predicate est appelé depuis la distribution et ne doit pas bloquer.
+func (em *EventManager) SubscribeFunc(predicate func(interfaces.Event) bool, opts ...interfaces.SubscribeOption) (<-chan interfaces.Event, error) {
+	select {
+	case <-em.CTX.Done():
+		return nil, em.CTX.Err()
+	default:
+		sub := newFilteredSubscriber(predicate, em.subscribeConfig(opts), em.stats)
+		em.mu.Lock()
+		defer em.mu.Unlock()
+		em.filtered = append(em.filtered, sub)
+		return sub.ch, nil
+	}
+}
+
+// subscribeConfig remplace BlockWithTimeout par Grow en distribution par tick:
+// dispatch bloquerait sous em.mu pendant tout le délai, puis perdrait l'événement
+// que l'abonné, mis à jour après le Flush, n'a jamais pu lire
+func (em *EventManager) subscribeConfig(opts []interfaces.SubscribeOption) interfaces.SubscribeConfig {
+	config := interfaces.NewSubscribeConfig(opts...)
+	if em.mode == DispatchPerTick && config.Overflow == interfaces.BlockWithTimeout {
+		config.Overflow = interfaces.Grow
+	}
+	return config
+}
+
+// Tick renvoie le nombre d'Update reçus, utilisé pour estampiller les événements
+func (em *EventManager) Tick() uint64 {
+	return em.tick.Load()
+}
+
 func (em *EventManager) dispatch(event interfaces.Event) {
+	event, keep := em.middlewares.apply(em.tick.Load(), event)
+	if !keep {
+		em.stats.forType(event.Type).vetoed.Add(1)
+		return
+	}
+
+	// en distribution asynchrone, un abonné BlockWithTimeout garde ce verrou
+	// jusqu'à son délai: Subscribe et Unsubscribe attendent d'autant
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	for _, sub := range em.subscribers[event.Type] {
+		sub.deliver(event)
+	}
+	for _, sub := range em.filtered {
+		if sub.match(event) {
+			sub.deliver(event)
+		}
+	}
+}
+
+// drainBacklogs pousse vers leurs canaux les événements retenus par les abonnés Grow
+func (em *EventManager) drainBacklogs() {
 	em.mu.RLock()
 	defer em.mu.RUnlock()
-	if subscribers, ok := em.subscribers[event.Type]; ok {
-		for _, ch := range subscribers {
-			select {
-			case ch <- event:
-				// success
-			default:
-				// channel is full, skip this subscriber
+	for _, subs := range em.subscribers {
+		for _, sub := range subs {
+			sub.drain()
+		}
+	}
+	for _, sub := range em.filtered {
+		sub.drain()
+	}
+}
+
+func (em *EventManager) findSubscriber(ch <-chan interfaces.Event) *subscriber {
+	for _, subs := range em.subscribers {
+		for _, sub := range subs {
+			if sub.ch == ch {
+				return sub
 			}
 		}
 	}
+	for _, sub := range em.filtered {
+		if sub.ch == ch {
+			return sub
+		}
+	}
+	return nil
 }
 
 func (em *EventManager) Unsubscribe(eventType interfaces.EventType, ch <-chan interfaces.Event) error {
@@ -109,16 +281,46 @@ func (em *EventManager) Unsubscribe(eventType interfaces.EventType, ch <-chan in
 		em.mu.Lock()
 		defer em.mu.Unlock()
 		if subscribers, ok := em.subscribers[eventType]; ok {
-			for i, subscriber := range subscribers {
-				if subscriber == ch {
-					close(subscriber)
+			for i, sub := range subscribers {
+				if sub.ch == ch {
+					close(sub.ch)
 					em.subscribers[eventType] = append(subscribers[:i], subscribers[i+1:]...)
-					break
+					return nil
 				}
 			}
 		}
+		// les abonnements multi-types ignorent eventType
+		for i, sub := range em.filtered {
+			if sub.ch == ch {
+				close(sub.ch)
+				em.filtered = append(em.filtered[:i], em.filtered[i+1:]...)
+				break
+			}
+		}
 		return nil
 	}
 }
 
+// PublishAfter publie l'événement après delay, mesuré en temps de jeu
+func (em *EventManager) PublishAfter(delay interfaces.GameDuration, eventType interfaces.EventType, data interface{}) (interfaces.TimerHandle, error) {
+	return em.timers.Add(delay, false, eventType, data)
+}
+
+// PublishEvery publie l'événement à chaque interval jusqu'à CancelTimer
+func (em *EventManager) PublishEvery(interval interfaces.GameDuration, eventType interfaces.EventType, data interface{}) (interfaces.TimerHandle, error) {
+	return em.timers.Add(interval, true, eventType, data)
+}
+
+func (em *EventManager) CancelTimer(handle interfaces.TimerHandle) bool {
+	return em.timers.Cancel(handle)
+}
+
+func (em *EventManager) PauseTimers() {
+	em.timers.SetPaused(true)
+}
+
+func (em *EventManager) ResumeTimers() {
+	em.timers.SetPaused(false)
+}
+
 var _ interfaces.EventManagerInterface = (*EventManager)(nil)
@@ -0,0 +1,253 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ajkula/shmup/interfaces"
+)
+
+func TestPerTickDispatchWaitsForUpdate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	em := NewEventManager(WithDispatchMode(DispatchPerTick))
+	if err := em.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize returned an error: %v", err)
+	}
+	first, _ := em.Subscribe(interfaces.LevelEvent)
+	second, _ := em.Subscribe(interfaces.LevelEvent)
+
+	em.Publish(interfaces.LevelEvent, 1)
+	em.Publish(interfaces.LevelEvent, 2)
+
+	if len(first) != 0 || len(second) != 0 {
+		t.Fatal("Events should not be delivered before Update")
+	}
+
+	if err := em.Update(0.16); err != nil {
+		t.Fatalf("Update returned an error: %v", err)
+	}
+
+	for _, ch := range []<-chan interfaces.Event{first, second} {
+		if len(ch) != 2 {
+			t.Fatalf("Expected 2 events after Update, got %d", len(ch))
+		}
+		if evt := <-ch; evt.Data != 1 {
+			t.Errorf("Expected first event payload 1, got %v", evt.Data)
+		}
+		if evt := <-ch; evt.Data != 2 {
+			t.Errorf("Expected second event payload 2, got %v", evt.Data)
+		}
+	}
+}
+
+func newTickEventManager(t *testing.T) (*EventManager, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	em := NewEventManager(WithDispatchMode(DispatchPerTick)).(*EventManager)
+	if err := em.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize returned an error: %v", err)
+	}
+	return em, cancel
+}
+
+func TestQueueSizeLimitsPendingEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	em := NewEventManager(WithDispatchMode(DispatchPerTick), WithQueueSize(2))
+	if err := em.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize returned an error: %v", err)
+	}
+	em.Subscribe(interfaces.LevelEvent)
+
+	for i := 0; i < 2; i++ {
+		if err := em.Publish(interfaces.LevelEvent, i); err != nil {
+			t.Fatalf("Publish %d returned an error: %v", i, err)
+		}
+	}
+	if err := em.Publish(interfaces.LevelEvent, 2); err == nil {
+		t.Error("Publish should fail once the queue is full")
+	}
+	if dropped := em.Stats()[interfaces.LevelEvent].Dropped; dropped != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", dropped)
+	}
+
+	em.Update(0)
+	if err := em.Publish(interfaces.LevelEvent, 3); err != nil {
+		t.Errorf("Publish should succeed after Update drained the queue: %v", err)
+	}
+}
+
+func TestOverflowDropNewest(t *testing.T) {
+	em, cancel := newTickEventManager(t)
+	defer cancel()
+
+	ch, _ := em.Subscribe(interfaces.LevelEvent, interfaces.WithBufferSize(2))
+	for i := 1; i <= 3; i++ {
+		em.Publish(interfaces.LevelEvent, i)
+	}
+	em.Update(0.16)
+
+	if evt := <-ch; evt.Data != 1 {
+		t.Errorf("Expected oldest event to be kept, got %v", evt.Data)
+	}
+	stats, ok := em.StatsFor(ch)
+	if !ok {
+		t.Fatal("StatsFor should find the subscriber")
+	}
+	if stats.Delivered != 2 || stats.Dropped != 1 {
+		t.Errorf("Subscriber stats: got %+v, want 2 delivered and 1 dropped", stats)
+	}
+	typeStats := em.Stats()[interfaces.LevelEvent]
+	if typeStats.Published != 3 || typeStats.Delivered != 2 || typeStats.Dropped != 1 {
+		t.Errorf("Event stats: got %+v", typeStats)
+	}
+}
+
+func TestOverflowDropOldest(t *testing.T) {
+	em, cancel := newTickEventManager(t)
+	defer cancel()
+
+	ch, _ := em.Subscribe(interfaces.LevelEvent,
+		interfaces.WithBufferSize(2),
+		interfaces.WithOverflow(interfaces.DropOldest))
+	for i := 1; i <= 3; i++ {
+		em.Publish(interfaces.LevelEvent, i)
+	}
+	em.Update(0.16)
+
+	if evt := <-ch; evt.Data != 2 {
+		t.Errorf("Expected oldest event to be dropped, got %v", evt.Data)
+	}
+	if evt := <-ch; evt.Data != 3 {
+		t.Errorf("Expected newest event to be kept, got %v", evt.Data)
+	}
+	if stats, _ := em.StatsFor(ch); stats.Dropped != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", stats.Dropped)
+	}
+}
+
+func TestOverflowBlockWithTimeout(t *testing.T) {
+	// hors distribution par tick, personne ne lit: le second événement attend puis se perd
+	config := interfaces.NewSubscribeConfig(
+		interfaces.WithBufferSize(1),
+		interfaces.WithBlockTimeout(time.Millisecond))
+	sub := newSubscriber(interfaces.LevelEvent, config, newStatsRegistry())
+	sub.deliver(interfaces.Event{Type: interfaces.LevelEvent, Data: 1})
+	sub.deliver(interfaces.Event{Type: interfaces.LevelEvent, Data: 2})
+
+	if stats := sub.stats(); stats.Delivered != 1 || stats.Dropped != 1 {
+		t.Errorf("Subscriber stats: got %+v, want 1 delivered and 1 dropped", stats)
+	}
+}
+
+func TestBlockWithTimeoutGrowsPerTick(t *testing.T) {
+	em, cancel := newTickEventManager(t)
+	defer cancel()
+
+	ch, _ := em.Subscribe(interfaces.LevelEvent,
+		interfaces.WithBufferSize(1),
+		interfaces.WithBlockTimeout(time.Second))
+	em.Publish(interfaces.LevelEvent, 1)
+	em.Publish(interfaces.LevelEvent, 2)
+
+	start := time.Now()
+	em.Update(0.16)
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Fatalf("Update should not wait for the block timeout, took %v", elapsed)
+	}
+
+	stats, _ := em.StatsFor(ch)
+	if stats.Overflow != interfaces.Grow || stats.Dropped != 0 || stats.Backlog != 1 {
+		t.Errorf("Subscriber stats: got %+v, want Grow with 1 event in backlog", stats)
+	}
+	for want := 1; want <= 2; want++ {
+		if evt := <-ch; evt.Data != want {
+			t.Errorf("Expected event %d, got %v", want, evt.Data)
+		}
+		em.Update(0.16)
+	}
+}
+
+func TestOverflowGrow(t *testing.T) {
+	em, cancel := newTickEventManager(t)
+	defer cancel()
+
+	ch, _ := em.Subscribe(interfaces.LevelEvent,
+		interfaces.WithBufferSize(1),
+		interfaces.WithOverflow(interfaces.Grow))
+	for i := 1; i <= 3; i++ {
+		em.Publish(interfaces.LevelEvent, i)
+	}
+	em.Update(0.16)
+
+	if stats, _ := em.StatsFor(ch); stats.Backlog != 2 || stats.Dropped != 0 {
+		t.Errorf("Subscriber stats: got %+v, want backlog 2 and no drop", stats)
+	}
+
+	for want := 1; want <= 3; want++ {
+		if evt := <-ch; evt.Data != want {
+			t.Errorf("Expected event %d, got %v", want, evt.Data)
+		}
+		em.Update(0.16)
+	}
+	if stats, _ := em.StatsFor(ch); stats.Delivered != 3 || stats.Backlog != 0 {
+		t.Errorf("Subscriber stats: got %+v, want 3 delivered and empty backlog", stats)
+	}
+}
+
+func TestSubscribeManyKeepsCrossTypeOrder(t *testing.T) {
+	em, cancel := newTickEventManager(t)
+	defer cancel()
+
+	ch, _ := em.SubscribeMany([]interfaces.EventType{interfaces.LevelEvent, interfaces.InputEvent})
+	em.Publish(interfaces.InputEvent, "shoot")
+	em.Publish(interfaces.ScoreEvent, 10)
+	em.Publish(interfaces.LevelEvent, 2)
+	em.Publish(interfaces.InputEvent, "left")
+	em.Update(0.16)
+
+	expected := []interfaces.EventType{interfaces.InputEvent, interfaces.LevelEvent, interfaces.InputEvent}
+	if len(ch) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(ch))
+	}
+	for i, eventType := range expected {
+		if evt := <-ch; evt.Type != eventType {
+			t.Errorf("Event %d: got type %v, want %v", i, evt.Type, eventType)
+		}
+	}
+}
+
+func TestSubscribeAllAndFunc(t *testing.T) {
+	em, cancel := newTickEventManager(t)
+	defer cancel()
+
+	all, _ := em.SubscribeAll()
+	big, _ := em.SubscribeFunc(func(evt interfaces.Event) bool {
+		score, ok := evt.Data.(int)
+		return ok && score >= 100
+	})
+	em.Publish(interfaces.ScoreEvent, 10)
+	em.Publish(interfaces.ScoreEvent, 500)
+	em.Publish(interfaces.InputEvent, "up")
+	em.Update(0.16)
+
+	if len(all) != 3 {
+		t.Errorf("SubscribeAll: expected 3 events, got %d", len(all))
+	}
+	if len(big) != 1 {
+		t.Fatalf("SubscribeFunc: expected 1 event, got %d", len(big))
+	}
+	if evt := <-big; evt.Data != 500 {
+		t.Errorf("SubscribeFunc: got %v, want 500", evt.Data)
+	}
+
+	if err := em.Unsubscribe(interfaces.ScoreEvent, big); err != nil {
+		t.Fatalf("Unsubscribe returned an error: %v", err)
+	}
+	if _, ok := <-big; ok {
+		t.Error("Filtered channel should be closed after Unsubscribe")
+	}
+}
@@ -0,0 +1,127 @@
+package event
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/ajkula/shmup/interfaces"
+)
+
+// Middleware peut réécrire un événement avant sa distribution, ou le bloquer en renvoyant false
+type Middleware func(tick uint64, evt interfaces.Event) (interfaces.Event, bool)
+
+type middlewareEntry struct {
+	name       string
+	order      int
+	middleware Middleware
+}
+
+type middlewareChain struct {
+	mu      sync.RWMutex
+	entries []middlewareEntry
+}
+
+func (c *middlewareChain) add(name string, order int, mw Middleware) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, entry := range c.entries {
+		if entry.name == name {
+			return fmt.Errorf("middleware %q already registered", name)
+		}
+	}
+	c.entries = append(c.entries, middlewareEntry{name: name, order: order, middleware: mw})
+	sort.SliceStable(c.entries, func(i, j int) bool {
+		return c.entries[i].order < c.entries[j].order
+	})
+	return nil
+}
+
+func (c *middlewareChain) remove(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, entry := range c.entries {
+		if entry.name == name {
+			c.entries = append(c.entries[:i], c.entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (c *middlewareChain) apply(tick uint64, evt interfaces.Event) (interfaces.Event, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, entry := range c.entries {
+		var keep bool
+		if evt, keep = entry.middleware(tick, evt); !keep {
+			return evt, false
+		}
+	}
+	return evt, true
+}
+
+// Use ajoute un middleware entre Publish et la distribution.
+// Les middlewares s'exécutent par order croissant, puis par ordre d'ajout.
+func (em *EventManager) Use(name string, order int, mw Middleware) error {
+	return em.middlewares.add(name, order, mw)
+}
+
+func (em *EventManager) RemoveMiddleware(name string) bool {
+	return em.middlewares.remove(name)
+}
+
+// Filter ne laisse passer que les événements acceptés par predicate
+func Filter(predicate func(interfaces.Event) bool) Middleware {
+	return func(tick uint64, evt interfaces.Event) (interfaces.Event, bool) {
+		return evt, predicate(evt)
+	}
+}
+
+// RateLimit laisse passer au plus max événements de eventType par tick
+func RateLimit(eventType interfaces.EventType, max int) Middleware {
+	var mu sync.Mutex
+	var currentTick uint64
+	count := 0
+	return func(tick uint64, evt interfaces.Event) (interfaces.Event, bool) {
+		if evt.Type != eventType {
+			return evt, true
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if tick != currentTick {
+			currentTick = tick
+			count = 0
+		}
+		count++
+		return evt, count <= max
+	}
+}
+
+// TraceLogger écrit une ligne par événement: tick, type et résumé de la charge utile
+func TraceLogger(w io.Writer) Middleware {
+	var mu sync.Mutex
+	return func(tick uint64, evt interfaces.Event) (interfaces.Event, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintf(w, "tick=%d type=%v payload=%s\n", tick, evt.Type, summarizePayload(evt.Data))
+		return evt, true
+	}
+}
+
+func summarizePayload(data interface{}) string {
+	if data == nil {
+		return "nil"
+	}
+	if s, ok := data.(fmt.Stringer); ok {
+		return s.String()
+	}
+	switch reflect.TypeOf(data).Kind() {
+	case reflect.Pointer, reflect.Struct, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return fmt.Sprintf("%T", data)
+	default:
+		return fmt.Sprintf("%q", fmt.Sprint(data))
+	}
+}
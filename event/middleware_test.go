@@ -0,0 +1,89 @@
+package event
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ajkula/shmup/interfaces"
+)
+
+func TestMiddlewaresRunInOrderAndCanRewrite(t *testing.T) {
+	em, cancel := newTickEventManager(t)
+	defer cancel()
+
+	var calls []string
+	em.Use("double", 20, func(tick uint64, evt interfaces.Event) (interfaces.Event, bool) {
+		calls = append(calls, "double")
+		evt.Data = evt.Data.(int) * 2
+		return evt, true
+	})
+	em.Use("increment", 10, func(tick uint64, evt interfaces.Event) (interfaces.Event, bool) {
+		calls = append(calls, "increment")
+		evt.Data = evt.Data.(int) + 1
+		return evt, true
+	})
+	if err := em.Use("double", 0, Filter(func(interfaces.Event) bool { return true })); err == nil {
+		t.Error("Expected duplicate middleware name to be rejected")
+	}
+
+	ch, _ := em.Subscribe(interfaces.ScoreEvent)
+	em.Publish(interfaces.ScoreEvent, 1)
+	em.Update(0.16)
+
+	if evt := <-ch; evt.Data != 4 {
+		t.Errorf("Expected rewritten payload 4, got %v", evt.Data)
+	}
+	if strings.Join(calls, ",") != "increment,double" {
+		t.Errorf("Unexpected middleware order: %v", calls)
+	}
+}
+
+func TestFilterAndRateLimitVetoEvents(t *testing.T) {
+	em, cancel := newTickEventManager(t)
+	defer cancel()
+
+	em.Use("no-input", 0, Filter(func(evt interfaces.Event) bool {
+		return evt.Type != interfaces.InputEvent
+	}))
+	em.Use("score-limit", 1, RateLimit(interfaces.ScoreEvent, 2))
+
+	inputs, _ := em.Subscribe(interfaces.InputEvent)
+	scores, _ := em.Subscribe(interfaces.ScoreEvent)
+	em.Publish(interfaces.InputEvent, "shoot")
+	for i := 0; i < 3; i++ {
+		em.Publish(interfaces.ScoreEvent, i)
+	}
+	em.Update(0.16)
+
+	if len(inputs) != 0 {
+		t.Error("Filtered event should not be delivered")
+	}
+	if len(scores) != 2 {
+		t.Errorf("Expected 2 rate limited events, got %d", len(scores))
+	}
+	if vetoed := em.Stats()[interfaces.ScoreEvent].Vetoed; vetoed != 1 {
+		t.Errorf("Expected 1 vetoed score event, got %d", vetoed)
+	}
+
+	em.Publish(interfaces.ScoreEvent, 3)
+	em.Update(0.16)
+	if len(scores) != 3 {
+		t.Error("Rate limit should reset on the next tick")
+	}
+}
+
+func TestTraceLogger(t *testing.T) {
+	em, cancel := newTickEventManager(t)
+	defer cancel()
+
+	var buf bytes.Buffer
+	em.Use("trace", 0, TraceLogger(&buf))
+	em.Publish(interfaces.ScoreEvent, 100)
+	em.Update(0.16)
+
+	line := strings.TrimSpace(buf.String())
+	if !strings.HasPrefix(line, "tick=1 ") || !strings.Contains(line, `payload="100"`) {
+		t.Errorf("Unexpected trace line: %q", line)
+	}
+}
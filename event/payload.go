@@ -0,0 +1,71 @@
+package event
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ajkula/shmup/interfaces"
+)
+
+// Topic lie un EventType au type de sa charge utile
+type Topic[T any] struct {
+	eventType interfaces.EventType
+}
+
+// NewTopic enregistre T comme type de charge utile de eventType.
+// Elle panique si eventType n'est pas enregistré ou porte déjà un autre type.
+func NewTopic[T any](eventType interfaces.EventType) Topic[T] {
+	payloadType := reflect.TypeOf((*T)(nil)).Elem()
+
+	if err := interfaces.SetEventPayload(eventType, payloadType); err != nil {
+		panic(err)
+	}
+	return Topic[T]{eventType: eventType}
+}
+
+func (t Topic[T]) Type() interfaces.EventType {
+	return t.eventType
+}
+
+func (t Topic[T]) Publish(em interfaces.EventManagerInterface, data T) error {
+	return em.Publish(t.eventType, data)
+}
+
+func (t Topic[T]) Subscribe(em interfaces.EventManagerInterface, opts ...interfaces.SubscribeOption) (<-chan interfaces.Event, error) {
+	return em.Subscribe(t.eventType, opts...)
+}
+
+// Payload renvoie la charge utile de evt si l'événement appartient à ce topic
+func (t Topic[T]) Payload(evt interfaces.Event) (T, bool) {
+	if evt.Type != t.eventType {
+		var zero T
+		return zero, false
+	}
+	data, ok := evt.Data.(T)
+	return data, ok
+}
+
+// PayloadType renvoie le type de charge utile enregistré pour eventType
+func PayloadType(eventType interfaces.EventType) (reflect.Type, bool) {
+	info, ok := interfaces.LookupEventType(eventType)
+	if !ok || info.Payload == nil {
+		return nil, false
+	}
+	return info.Payload, true
+}
+
+// ValidatePayload rejette une charge utile incompatible avec le type enregistré.
+// Les EventType sans type enregistré acceptent n'importe quelle charge utile.
+func ValidatePayload(eventType interfaces.EventType, data interface{}) error {
+	payloadType, ok := PayloadType(eventType)
+	if !ok {
+		return nil
+	}
+	if data == nil {
+		return fmt.Errorf("event %v: nil payload, expected %v", eventType, payloadType)
+	}
+	if !reflect.TypeOf(data).AssignableTo(payloadType) {
+		return fmt.Errorf("event %v: payload of type %T, expected %v", eventType, data, payloadType)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajkula/shmup/interfaces"
+)
+
+var (
+	testIntEvent      = interfaces.RegisterEventType("testIntEvent", "int payload used by the event tests")
+	testStringerEvent = interfaces.RegisterEventType("testStringerEvent", "Stringer payload used by the event tests")
+)
+
+type testStringer struct{}
+
+func (testStringer) String() string { return "test" }
+
+func TestTopicRejectsWrongPayload(t *testing.T) {
+	NewTopic[int](testIntEvent)
+
+	if err := ValidatePayload(testIntEvent, 42); err != nil {
+		t.Errorf("Expected int payload to be accepted, got %v", err)
+	}
+	if err := ValidatePayload(testIntEvent, "42"); err == nil {
+		t.Error("Expected string payload to be rejected")
+	}
+	if err := ValidatePayload(testIntEvent, nil); err == nil {
+		t.Error("Expected nil payload to be rejected")
+	}
+}
+
+func TestTopicAcceptsInterfaceImplementations(t *testing.T) {
+	NewTopic[interface{ String() string }](testStringerEvent)
+
+	if err := ValidatePayload(testStringerEvent, testStringer{}); err != nil {
+		t.Errorf("Expected implementation to be accepted, got %v", err)
+	}
+	if err := ValidatePayload(testStringerEvent, 1); err == nil {
+		t.Error("Expected non implementation to be rejected")
+	}
+}
+
+func TestNewTopicPanicsOnConflictingPayload(t *testing.T) {
+	NewTopic[int](testIntEvent)
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected NewTopic to panic on conflicting payload type")
+		}
+	}()
+	NewTopic[string](testIntEvent)
+}
+
+func TestEventManagerPublishValidatesPayload(t *testing.T) {
+	topic := NewTopic[int](testIntEvent)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	em := NewEventManager()
+	if err := em.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize returned an error: %v", err)
+	}
+	ch, _ := topic.Subscribe(em)
+
+	if err := em.Publish(testIntEvent, "wrong"); err == nil {
+		t.Error("Expected Publish to reject a wrong payload")
+	}
+	if err := topic.Publish(em, 7); err != nil {
+		t.Fatalf("Publish returned an error: %v", err)
+	}
+
+	evt := <-ch
+	value, ok := topic.Payload(evt)
+	if !ok || value != 7 {
+		t.Errorf("Payload: got (%v, %v), want (7, true)", value, ok)
+	}
+}
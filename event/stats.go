@@ -0,0 +1,91 @@
+package event
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/ajkula/shmup/interfaces"
+)
+
+// les compteurs sont déclarés dans interfaces pour être exposés par EventManagerInterface
+type (
+	EventStats      = interfaces.EventStats
+	SubscriberStats = interfaces.SubscriberStats
+)
+
+type eventCounters struct {
+	published atomic.Uint64
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+	vetoed    atomic.Uint64
+}
+
+type statsRegistry struct {
+	mu     sync.RWMutex
+	byType map[interfaces.EventType]*eventCounters
+}
+
+func newStatsRegistry() *statsRegistry {
+	return &statsRegistry{
+		byType: make(map[interfaces.EventType]*eventCounters),
+	}
+}
+
+func (r *statsRegistry) forType(eventType interfaces.EventType) *eventCounters {
+	r.mu.RLock()
+	counters, ok := r.byType[eventType]
+	r.mu.RUnlock()
+	if ok {
+		return counters
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if counters, ok = r.byType[eventType]; !ok {
+		counters = &eventCounters{}
+		r.byType[eventType] = counters
+	}
+	return counters
+}
+
+// Stats renvoie les compteurs publiés / distribués / perdus par EventType.
+// Un événement perdu à la publication n'a jamais atteint d'abonné.
+func (em *EventManager) Stats() map[interfaces.EventType]EventStats {
+	em.stats.mu.RLock()
+	defer em.stats.mu.RUnlock()
+	stats := make(map[interfaces.EventType]EventStats, len(em.stats.byType))
+	for eventType, counters := range em.stats.byType {
+		stats[eventType] = EventStats{
+			Published: counters.published.Load(),
+			Delivered: counters.delivered.Load(),
+			Dropped:   counters.dropped.Load(),
+			Vetoed:    counters.vetoed.Load(),
+		}
+	}
+	return stats
+}
+
+func (em *EventManager) SubscriberStats() []SubscriberStats {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	var stats []SubscriberStats
+	for _, subs := range em.subscribers {
+		for _, sub := range subs {
+			stats = append(stats, sub.stats())
+		}
+	}
+	for _, sub := range em.filtered {
+		stats = append(stats, sub.stats())
+	}
+	return stats
+}
+
+// StatsFor renvoie les compteurs de l'abonné propriétaire de ch
+func (em *EventManager) StatsFor(ch <-chan interfaces.Event) (SubscriberStats, bool) {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	if sub := em.findSubscriber(ch); sub != nil {
+		return sub.stats(), true
+	}
+	return SubscriberStats{}, false
+}
@@ -0,0 +1,136 @@
+package event
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ajkula/shmup/interfaces"
+)
+
+type subscriber struct {
+	eventType interfaces.EventType
+	// match est non nil pour les abonnements multi-types
+	match     func(interfaces.Event) bool
+	ch        chan interfaces.Event
+	config    interfaces.SubscribeConfig
+	registry  *statsRegistry
+	mu        sync.Mutex
+	backlog   []interfaces.Event
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+func newSubscriber(eventType interfaces.EventType, config interfaces.SubscribeConfig, stats *statsRegistry) *subscriber {
+	return &subscriber{
+		eventType: eventType,
+		ch:        make(chan interfaces.Event, config.BufferSize),
+		config:    config,
+		registry:  stats,
+	}
+}
+
+func newFilteredSubscriber(match func(interfaces.Event) bool, config interfaces.SubscribeConfig, stats *statsRegistry) *subscriber {
+	return &subscriber{
+		match:    match,
+		ch:       make(chan interfaces.Event, config.BufferSize),
+		config:   config,
+		registry: stats,
+	}
+}
+
+func (s *subscriber) deliver(event interfaces.Event) {
+	switch s.config.Overflow {
+	case interfaces.DropOldest:
+		for {
+			select {
+			case s.ch <- event:
+				s.markDelivered(event)
+				return
+			default:
+				select {
+				case oldest := <-s.ch:
+					s.markDropped(oldest)
+				default:
+				}
+			}
+		}
+	case interfaces.BlockWithTimeout:
+		select {
+		case s.ch <- event:
+			s.markDelivered(event)
+			return
+		default:
+		}
+		timer := time.NewTimer(s.config.BlockTimeout)
+		defer timer.Stop()
+		select {
+		case s.ch <- event:
+			s.markDelivered(event)
+		case <-timer.C:
+			s.markDropped(event)
+		}
+	case interfaces.Grow:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		// le backlog doit se vider avant tout nouvel événement pour garder l'ordre
+		s.backlog = append(s.backlog, event)
+		s.drainLocked()
+	default:
+		select {
+		case s.ch <- event:
+			s.markDelivered(event)
+		default:
+			s.markDropped(event)
+		}
+	}
+}
+
+func (s *subscriber) drain() {
+	if s.config.Overflow != interfaces.Grow {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.drainLocked()
+}
+
+func (s *subscriber) drainLocked() {
+	sent := 0
+	for _, event := range s.backlog {
+		select {
+		case s.ch <- event:
+			s.markDelivered(event)
+			sent++
+			continue
+		default:
+		}
+		break
+	}
+	s.backlog = s.backlog[sent:]
+}
+
+func (s *subscriber) markDelivered(event interfaces.Event) {
+	s.delivered.Add(1)
+	s.registry.forType(event.Type).delivered.Add(1)
+}
+
+func (s *subscriber) markDropped(event interfaces.Event) {
+	s.dropped.Add(1)
+	s.registry.forType(event.Type).dropped.Add(1)
+}
+
+func (s *subscriber) stats() SubscriberStats {
+	s.mu.Lock()
+	backlog := len(s.backlog)
+	s.mu.Unlock()
+	return SubscriberStats{
+		EventType: s.eventType,
+		Filtered:  s.match != nil,
+		Overflow:  s.config.Overflow,
+		Delivered: s.delivered.Load(),
+		Dropped:   s.dropped.Load(),
+		Queued:    len(s.ch),
+		Backlog:   backlog,
+	}
+}
@@ -0,0 +1,128 @@
+package event
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ajkula/shmup/interfaces"
+)
+
+type timer struct {
+	handle    interfaces.TimerHandle
+	event     interfaces.Event
+	interval  interfaces.GameDuration
+	repeat    bool
+	ticksLeft int
+	timeLeft  float64
+}
+
+func (t *timer) reset() {
+	t.ticksLeft = t.interval.Ticks
+	t.timeLeft += t.interval.Seconds
+}
+
+// TimerQueue gère des événements différés sur le temps de jeu.
+// Il n'avance que par Advance: tant que le jeu ne tourne pas, rien n'expire.
+type TimerQueue struct {
+	mu     sync.Mutex
+	next   interfaces.TimerHandle
+	timers []*timer
+	paused bool
+}
+
+func NewTimerQueue() *TimerQueue {
+	return &TimerQueue{
+		timers: make([]*timer, 0),
+	}
+}
+
+func (q *TimerQueue) Add(delay interfaces.GameDuration, repeat bool, eventType interfaces.EventType, data interface{}) (interfaces.TimerHandle, error) {
+	if delay.Ticks < 0 || delay.Seconds < 0 {
+		return 0, fmt.Errorf("timer for event %v: negative delay %+v", eventType, delay)
+	}
+	if repeat && !delay.InTicks() && delay.Seconds == 0 {
+		return 0, fmt.Errorf("timer for event %v: repeating interval must be positive", eventType)
+	}
+	if err := ValidatePayload(eventType, data); err != nil {
+		return 0, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.next++
+	t := &timer{
+		handle:   q.next,
+		event:    interfaces.Event{Type: eventType, Data: data},
+		interval: delay,
+		repeat:   repeat,
+	}
+	t.reset()
+	q.timers = append(q.timers, t)
+	return t.handle, nil
+}
+
+func (q *TimerQueue) Cancel(handle interfaces.TimerHandle) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, t := range q.timers {
+		if t.handle == handle {
+			q.timers = append(q.timers[:i], q.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (q *TimerQueue) SetPaused(paused bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.paused = paused
+}
+
+func (q *TimerQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.timers)
+}
+
+// Advance fait avancer les timers d'un tick de deltaTime secondes et renvoie
+// les événements échus, dans l'ordre de création des timers.
+func (q *TimerQueue) Advance(deltaTime float64) []interfaces.Event {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.paused {
+		return nil
+	}
+
+	var fired []interfaces.Event
+	remaining := q.timers[:0]
+	for _, t := range q.timers {
+		expired := false
+		if t.interval.InTicks() {
+			t.ticksLeft--
+			if t.ticksLeft <= 0 {
+				fired = append(fired, t.event)
+				expired = true
+			}
+		} else {
+			t.timeLeft -= deltaTime
+			for t.timeLeft <= 0 {
+				fired = append(fired, t.event)
+				expired = true
+				if !t.repeat {
+					break
+				}
+				t.reset()
+			}
+		}
+
+		if !expired || t.repeat {
+			if expired && t.interval.InTicks() {
+				t.reset()
+			}
+			remaining = append(remaining, t)
+		}
+	}
+	q.timers = remaining
+	return fired
+}
@@ -0,0 +1,122 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ajkula/shmup/interfaces"
+)
+
+func TestTimerQueueTicks(t *testing.T) {
+	q := NewTimerQueue()
+	q.Add(interfaces.Ticks(3), false, interfaces.LevelEvent, 1)
+
+	for tick := 1; tick <= 2; tick++ {
+		if fired := q.Advance(1.0 / 60.0); len(fired) != 0 {
+			t.Fatalf("Timer fired early on tick %d", tick)
+		}
+	}
+	fired := q.Advance(1.0 / 60.0)
+	if len(fired) != 1 || fired[0].Type != interfaces.LevelEvent || fired[0].Data != 1 {
+		t.Fatalf("Expected timer to fire on tick 3, got %v", fired)
+	}
+	if q.Len() != 0 {
+		t.Error("One-shot timer should be removed after firing")
+	}
+}
+
+func TestTimerQueueRepeatingSeconds(t *testing.T) {
+	q := NewTimerQueue()
+	handle, err := q.Add(interfaces.Seconds(0.5), true, interfaces.InputEvent, "shoot")
+	if err != nil {
+		t.Fatalf("Add returned an error: %v", err)
+	}
+
+	count := 0
+	for i := 0; i < 10; i++ {
+		count += len(q.Advance(0.25))
+	}
+	if count != 5 {
+		t.Errorf("Expected 5 firings in 2.5s, got %d", count)
+	}
+
+	if !q.Cancel(handle) {
+		t.Fatal("Cancel should find the timer")
+	}
+	if fired := q.Advance(1); len(fired) != 0 {
+		t.Error("Cancelled timer should not fire")
+	}
+}
+
+func TestTimerQueuePause(t *testing.T) {
+	q := NewTimerQueue()
+	q.Add(interfaces.Ticks(1), false, interfaces.LevelEvent, 1)
+
+	q.SetPaused(true)
+	if fired := q.Advance(1); len(fired) != 0 {
+		t.Error("Paused timers should not fire")
+	}
+	q.SetPaused(false)
+	if fired := q.Advance(1); len(fired) != 1 {
+		t.Error("Resumed timer should fire")
+	}
+}
+
+func TestTimerQueueRejectsInvalidTimers(t *testing.T) {
+	NewTopic[int](testIntEvent)
+	q := NewTimerQueue()
+
+	if _, err := q.Add(interfaces.Seconds(-1), false, interfaces.LevelEvent, 1); err == nil {
+		t.Error("Expected negative delay to be rejected")
+	}
+	if _, err := q.Add(interfaces.Seconds(0), true, interfaces.LevelEvent, 1); err == nil {
+		t.Error("Expected zero repeating interval to be rejected")
+	}
+	if _, err := q.Add(interfaces.Ticks(1), false, testIntEvent, "wrong"); err == nil {
+		t.Error("Expected wrong payload to be rejected")
+	}
+}
+
+func TestEventManagerPublishAfter(t *testing.T) {
+	em, cancel := newTickEventManager(t)
+	defer cancel()
+
+	ch, _ := em.Subscribe(interfaces.LevelEvent)
+	em.PublishAfter(interfaces.Ticks(2), interfaces.LevelEvent, 5)
+
+	em.Update(1.0 / 60.0)
+	if len(ch) != 0 {
+		t.Fatal("Delayed event delivered too early")
+	}
+	em.Update(1.0 / 60.0)
+	if len(ch) != 1 {
+		t.Fatal("Delayed event should be delivered on its tick")
+	}
+}
+
+func TestEventManagerLogsFailedTimerPublish(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	em := NewEventManager(WithDispatchMode(DispatchPerTick), WithQueueSize(1))
+	if err := em.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize returned an error: %v", err)
+	}
+	em.Subscribe(interfaces.LevelEvent)
+
+	// la file est pleine quand le timer échoit
+	em.PublishAfter(interfaces.Ticks(1), interfaces.LevelEvent, 2)
+	em.Publish(interfaces.LevelEvent, 1)
+	em.Update(1.0 / 60.0)
+
+	if !strings.Contains(buf.String(), "LevelEvent") {
+		t.Errorf("Expected the failed timer publish to be logged, got %q", buf.String())
+	}
+}
@@ -1,113 +1,68 @@
+// Package game ouvre la simulation dans une fenêtre ebiten: boucle à pas fixe
+// sur l'horloge murale, rendu, clavier et manettes.
 package game
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"sync"
 	"time"
 
 	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/core"
-	"github.com/ajkula/shmup/entity"
-	"github.com/ajkula/shmup/event"
-	"github.com/ajkula/shmup/manager"
-	"github.com/ajkula/shmup/state"
-	"github.com/ajkula/shmup/system"
+	"github.com/ajkula/shmup/sim"
 	"github.com/ajkula/shmup/types"
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
-const (
-	fixedDeltaTime = 1.0 / 60.0 // 60 fps
-	maxDeltaTime   = 1.0 / 10.0 // max time between updates (10 fps)
-)
+const maxDeltaTime = 1.0 / 10.0 // max time between updates (10 fps)
 
 type Game struct {
-	ctx            context.Context
-	cancel         context.CancelFunc
-	systems        []core.System
+	sim            *sim.Simulation
 	lastUpdateTime time.Time
 	accumulator    float64
-	player         *entity.Player
-	wg             sync.WaitGroup
-	updateChan     chan float64
 	errChan        chan error
 }
 
-func NewGame(ctx context.Context) (*Game, error) {
-	gameCtx, cancel := context.WithCancel(ctx)
-
-	eventManager := event.NewEventManager()
-	if err := eventManager.Initialize(gameCtx); err != nil {
-		cancel()
-		return nil, fmt.Errorf("failed to initialize event manager: %w", err)
+// NewGame construit la simulation de cfg avec le clavier et les manettes comme
+// source d'entrée, sauf si sim.WithInputSource en impose une autre
+func NewGame(ctx context.Context, cfg config.GameConfig, opts ...sim.Option) (*Game, error) {
+	live := NewLiveInput()
+	if err := live.SetBindings(cfg.Bindings); err != nil {
+		return nil, err
 	}
-	stateManager := state.NewStateManager(eventManager)
+	live.SetDeadzone(cfg.GamepadDeadzone)
 
-	g := &Game{
-		ctx:            gameCtx,
-		cancel:         cancel,
-		systems:        make([]core.System, 0),
+	opts = append([]sim.Option{sim.WithConfig(cfg), sim.WithInputSource(live)}, opts...)
+	s, err := sim.NewSimulation(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Game{
+		sim:            s,
 		lastUpdateTime: time.Now(),
 		accumulator:    0,
-		updateChan:     make(chan float64, 1),
 		errChan:        make(chan error, 1),
-	}
-
-	// initialize systems
-	renderSystem := system.NewRenderSystem()
-	collisionSystem := system.NewCollisionSystem(eventManager)
-	inputSystem := system.NewInputSystem(eventManager)
-	updateSystem := system.NewUpdateSystem()
-
-	// initialize managers
-	enemyManager := manager.NewEnemyManager(eventManager)
-	bulletManager := manager.NewBulletManager(eventManager)
-	scoreManager := manager.NewScoreManager(eventManager)
-	levelManager := manager.NewLevelManager(eventManager)
-
-	// add all systems and managers to the game
-	g.systems = append(g.systems,
-		eventManager,
-		stateManager,
-		renderSystem,
-		collisionSystem,
-		inputSystem,
-		updateSystem,
-		enemyManager,
-		bulletManager,
-		scoreManager,
-		levelManager,
-	)
-
-	// initialize all systems
-	for _, sys := range g.systems {
-		if err := sys.Initialize(gameCtx); err != nil {
-			cancel()
-			return nil, fmt.Errorf("failed to initialize system: %w", err)
-		}
-	}
-
-	// create player
-	g.player = entity.NewPlayer(
-		types.Vector2D{
-			X: float64(config.Config.ScreenWidth / 2),
-			Y: float64(config.Config.ScreenHeight - 50),
-		},
-		eventManager,
-	)
-
-	return g, nil
+	}, nil
 }
 
 func (g *Game) Update() error {
+	// une erreur critique est prioritaire sur l'annulation qu'elle a déclenchée
 	select {
-	case <-g.ctx.Done():
-		return g.ctx.Err()
-	case err := <-g.errChan:
+	case err, ok := <-g.errChan:
+		if !ok {
+			return g.sim.Context().Err()
+		}
 		g.Shutdown()
 		return fmt.Errorf("critical error occurred: %w", err)
+	default:
+	}
+
+	select {
+	case <-g.sim.Context().Done():
+		return g.sim.Context().Err()
 	default:
 		currentTime := time.Now()
 		deltaTime := currentTime.Sub(g.lastUpdateTime).Seconds()
@@ -119,14 +74,15 @@ func (g *Game) Update() error {
 
 		g.accumulator += deltaTime
 
-		for g.accumulator >= fixedDeltaTime {
-			select {
-			case <-g.ctx.Done():
-				return g.ctx.Err()
-			default:
-				g.updateChan <- fixedDeltaTime
-				g.accumulator -= fixedDeltaTime
+		for g.accumulator >= core.FixedDeltaTime {
+			if err := g.sim.Tick(); err != nil {
+				if !errors.Is(err, context.Canceled) {
+					log.Printf("Error running system: %v", err)
+				}
+				g.handleCriticalError(err)
+				return nil
 			}
+			g.accumulator -= core.FixedDeltaTime
 		}
 	}
 
@@ -134,38 +90,17 @@ func (g *Game) Update() error {
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
-	if renderSystem, ok := g.systems[2].(*system.RenderSystem); ok {
-		renderSystem.Render(screen)
-	}
+	g.sim.Draw(ebitenScreen{screen})
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
-	return config.Config.ScreenWidth, config.Config.ScreenHeight
+	cfg := g.sim.Config()
+	return cfg.ScreenWidth, cfg.ScreenHeight
 }
 
-func (g *Game) Run() {
-	g.wg.Add(len(g.systems))
-	for _, sys := range g.systems {
-		go func(s core.System) {
-			defer g.wg.Done()
-
-			for {
-				select {
-				case <-g.ctx.Done():
-					return
-				case dt := <-g.updateChan:
-					if err := s.Update(dt); err != nil {
-						if err != context.Canceled {
-							log.Printf("Error running system: %v", err)
-						}
-						g.handleCriticalError(err)
-						return
-					}
-				}
-			}
-
-		}(sys)
-	}
+// Simulation renvoie la partie pilotée par cette fenêtre
+func (g *Game) Simulation() *sim.Simulation {
+	return g.sim
 }
 
 func (g *Game) handleCriticalError(err error) {
@@ -174,15 +109,24 @@ func (g *Game) handleCriticalError(err error) {
 	default:
 		log.Printf("Critical error occurred but error channel is full: %v", err)
 	}
-	g.cancel()
+	g.sim.Stop()
 }
 
 func (g *Game) Shutdown() {
-	g.cancel()
-	for _, sys := range g.systems {
-		sys.Shutdown()
-	}
-	g.wg.Wait()
-	close(g.updateChan)
+	g.sim.Shutdown()
 	close(g.errChan)
 }
+
+// ebitenScreen donne à la simulation une types.Screen sur l'image ebiten
+type ebitenScreen struct {
+	*ebiten.Image
+}
+
+func (s ebitenScreen) DebugPrintAt(text string, x, y int) {
+	ebitenutil.DebugPrintAt(s.Image, text, x, y)
+}
+
+var (
+	_ ebiten.Game  = (*Game)(nil)
+	_ types.Screen = ebitenScreen{}
+)
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"log"
+
+	"github.com/ajkula/shmup/input"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+var standardPadButtons = map[ebiten.StandardGamepadButton]input.PadButton{
+	ebiten.StandardGamepadButtonRightBottom:      input.PadA,
+	ebiten.StandardGamepadButtonRightRight:       input.PadB,
+	ebiten.StandardGamepadButtonRightLeft:        input.PadX,
+	ebiten.StandardGamepadButtonRightTop:         input.PadY,
+	ebiten.StandardGamepadButtonFrontTopLeft:     input.PadLB,
+	ebiten.StandardGamepadButtonFrontTopRight:    input.PadRB,
+	ebiten.StandardGamepadButtonFrontBottomLeft:  input.PadLT,
+	ebiten.StandardGamepadButtonFrontBottomRight: input.PadRT,
+	ebiten.StandardGamepadButtonCenterLeft:       input.PadSelect,
+	ebiten.StandardGamepadButtonCenterRight:      input.PadStart,
+	ebiten.StandardGamepadButtonLeftTop:          input.PadDPadUp,
+	ebiten.StandardGamepadButtonLeftBottom:       input.PadDPadDown,
+	ebiten.StandardGamepadButtonLeftLeft:         input.PadDPadLeft,
+	ebiten.StandardGamepadButtonLeftRight:        input.PadDPadRight,
+}
+
+// ebitenGamepads lit les manettes à disposition standard connectées à ce tick
+type ebitenGamepads struct {
+	ids     []ebiten.GamepadID
+	buttons []ebiten.StandardGamepadButton
+}
+
+func (p *ebitenGamepads) Gamepads() []input.PadState {
+	for _, id := range inpututil.AppendJustConnectedGamepadIDs(nil) {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			log.Printf("gamepad %d connected: %s", id, ebiten.GamepadName(id))
+		} else {
+			log.Printf("gamepad %d connected without a standard layout, ignored: %s", id, ebiten.GamepadName(id))
+		}
+	}
+	for _, id := range p.ids {
+		if inpututil.IsGamepadJustDisconnected(id) {
+			log.Printf("gamepad %d disconnected", id)
+		}
+	}
+
+	p.ids = ebiten.AppendGamepadIDs(p.ids[:0])
+	pads := make([]input.PadState, 0, len(p.ids))
+	for _, id := range p.ids {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		pad := input.PadState{
+			ID:    int(id),
+			LeftX: ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal),
+			LeftY: ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical),
+		}
+		p.buttons = inpututil.AppendPressedStandardGamepadButtons(id, p.buttons[:0])
+		for _, button := range p.buttons {
+			if name, ok := standardPadButtons[button]; ok {
+				pad.Buttons = append(pad.Buttons, name)
+			}
+		}
+		pads = append(pads, pad)
+	}
+	return pads
+}
+
+var _ input.GamepadSource = (*ebitenGamepads)(nil)
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/input"
+	"github.com/ajkula/shmup/types"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// LiveInput lit le clavier et les manettes; c'est la source d'InputSystem
+// quand aucune n'est imposée par WithInputSource
+type LiveInput struct {
+	bindings  input.Bindings
+	keys      map[input.Action][]ebiten.Key
+	gamepads  *input.Gamepads
+	padSource input.GamepadSource
+	// capture reçoit la prochaine touche pressée au lieu de produire des actions
+	capture func(key string)
+}
+
+func NewLiveInput() *LiveInput {
+	l := &LiveInput{
+		gamepads:  input.NewGamepads(input.DefaultPadBindings(), input.DefaultDeadzone),
+		padSource: &ebitenGamepads{},
+	}
+	if err := l.SetBindings(input.DefaultBindings()); err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// SetBindings remplace la table de touches; les noms de touches inconnus sont une erreur
+func (l *LiveInput) SetBindings(bindings input.Bindings) error {
+	keys := make(map[input.Action][]ebiten.Key, len(bindings))
+	for action, names := range bindings {
+		for _, name := range names {
+			var key ebiten.Key
+			if err := key.UnmarshalText([]byte(name)); err != nil {
+				return fmt.Errorf("invalid binding for %s: %w", action, err)
+			}
+			keys[action] = append(keys[action], key)
+		}
+	}
+	l.bindings = bindings.Clone()
+	l.keys = keys
+	return nil
+}
+
+func (l *LiveInput) Bindings() input.Bindings {
+	return l.bindings.Clone()
+}
+
+func (l *LiveInput) SetDeadzone(deadzone float64) {
+	l.gamepads.Deadzone = deadzone
+}
+
+// ApplyConfig reprend les touches et la deadzone rechargées depuis la config
+func (l *LiveInput) ApplyConfig(cfg config.GameConfig) {
+	if err := l.SetBindings(cfg.Bindings); err != nil {
+		log.Printf("config reload: keeping current bindings: %v", err)
+	}
+	l.SetDeadzone(cfg.GamepadDeadzone)
+}
+
+// CaptureNextKey suspend les actions jusqu'à la prochaine touche pressée.
+// Escape annule la capture avec un nom vide.
+func (l *LiveInput) CaptureNextKey(fn func(key string)) {
+	l.capture = fn
+}
+
+func (l *LiveInput) captureKey() {
+	pressed := inpututil.AppendJustPressedKeys(nil)
+	if len(pressed) == 0 {
+		return
+	}
+	fn := l.capture
+	l.capture = nil
+	if pressed[0] == ebiten.KeyEscape {
+		fn("")
+		return
+	}
+	fn(pressed[0].String())
+}
+
+func (l *LiveInput) Poll(tick uint64) input.State {
+	if l.capture != nil {
+		l.captureKey()
+		return input.State{}
+	}
+	pressed := make(map[input.Action]bool)
+	held := make(map[input.Action]bool)
+	for action, keys := range l.keys {
+		for _, key := range keys {
+			pressed[action] = pressed[action] || inpututil.IsKeyJustPressed(key)
+			held[action] = held[action] || ebiten.IsKeyPressed(key)
+		}
+	}
+	padPressed, padHeld := l.gamepads.Poll(l.padSource)
+	for _, action := range padPressed {
+		pressed[action] = true
+	}
+	for _, action := range padHeld {
+		held[action] = true
+	}
+
+	var state input.State
+	for _, action := range input.Actions() {
+		if pressed[action] {
+			state.Pressed = append(state.Pressed, action)
+		}
+		if held[action] {
+			state.Held = append(state.Held, action)
+		}
+	}
+	return state
+}
+
+var (
+	_ input.Rebindable   = (*LiveInput)(nil)
+	_ types.Configurable = (*LiveInput)(nil)
+)
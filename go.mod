@@ -2,7 +2,10 @@ module github.com/ajkula/shmup
 
 go 1.21.0
 
-require github.com/hajimehoshi/ebiten/v2 v2.7.8
+require (
+	github.com/hajimehoshi/ebiten/v2 v2.7.8
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/ebitengine/gomobile v0.0.0-20240518074828-e86332849895 // indirect
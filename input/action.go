@@ -0,0 +1,77 @@
+// Package input définit les actions du joueur et la table de touches qui les déclenche.
+//
+// Les actions circulent comme commandes InputEvent: le nom de l'action quand
+// elle est déclenchée, préfixé par "hold:" à chaque tick où elle reste maintenue.
+// Les valeurs reprennent les anciennes commandes pour rester compatibles avec
+// les enregistrements existants.
+package input
+
+import "strings"
+
+type Action string
+
+const (
+	MoveUp    Action = "up"
+	MoveDown  Action = "down"
+	MoveLeft  Action = "left"
+	MoveRight Action = "right"
+	Fire      Action = "shoot"
+	Bomb      Action = "bomb"
+	Focus     Action = "focus"
+	Pause     Action = "pause"
+	Confirm   Action = "confirm"
+	Back      Action = "back"
+)
+
+var actions = []Action{MoveUp, MoveDown, MoveLeft, MoveRight, Fire, Bomb, Focus, Pause, Confirm, Back}
+
+var actionLabels = map[Action]string{
+	MoveUp:    "Move up",
+	MoveDown:  "Move down",
+	MoveLeft:  "Move left",
+	MoveRight: "Move right",
+	Fire:      "Fire",
+	Bomb:      "Bomb",
+	Focus:     "Focus",
+	Pause:     "Pause",
+	Confirm:   "Confirm",
+	Back:      "Back",
+}
+
+const heldPrefix = "hold:"
+
+// Actions renvoie toutes les actions, dans l'ordre de l'écran d'options
+func Actions() []Action {
+	return append([]Action{}, actions...)
+}
+
+func (a Action) Valid() bool {
+	_, ok := actionLabels[a]
+	return ok
+}
+
+// Label renvoie le nom affiché à l'écran
+func (a Action) Label() string {
+	if label, ok := actionLabels[a]; ok {
+		return label
+	}
+	return string(a)
+}
+
+// Pressed est la commande publiée au tick où l'action est déclenchée
+func (a Action) Pressed() string {
+	return string(a)
+}
+
+// Held est la commande publiée à chaque tick où l'action reste maintenue
+func (a Action) Held() string {
+	return heldPrefix + string(a)
+}
+
+// Parse décode une commande InputEvent en action, et indique si elle est maintenue
+func Parse(command string) (Action, bool) {
+	if action, held := strings.CutPrefix(command, heldPrefix); held {
+		return Action(action), true
+	}
+	return Action(command), false
+}
@@ -0,0 +1,77 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Bindings associe à chaque action les noms des touches qui la déclenchent,
+// tels qu'écrits par ebiten.Key.String ("Space", "ArrowUp", "ShiftLeft"...)
+type Bindings map[Action][]string
+
+func DefaultBindings() Bindings {
+	return Bindings{
+		MoveUp:    {"ArrowUp"},
+		MoveDown:  {"ArrowDown"},
+		MoveLeft:  {"ArrowLeft"},
+		MoveRight: {"ArrowRight"},
+		Fire:      {"Space"},
+		Bomb:      {"X"},
+		Focus:     {"ShiftLeft", "ShiftRight"},
+		Pause:     {"P"},
+		Confirm:   {"Enter"},
+		Back:      {"Escape"},
+	}
+}
+
+func (b Bindings) Clone() Bindings {
+	clone := make(Bindings, len(b))
+	for action, keys := range b {
+		clone[action] = slices.Clone(keys)
+	}
+	return clone
+}
+
+// ActionFor renvoie l'action déclenchée par key
+func (b Bindings) ActionFor(key string) (Action, bool) {
+	for _, action := range actions {
+		if slices.Contains(b[action], key) {
+			return action, true
+		}
+	}
+	return "", false
+}
+
+// Rebind renvoie une copie où key est la seule touche de action. Si key
+// servait déjà une autre action, celle-ci reprend l'ancienne touche de action,
+// pour qu'aucune action ne se retrouve sans touche.
+func (b Bindings) Rebind(action Action, key string) Bindings {
+	rebound := b.Clone()
+	previous := rebound[action]
+	if other, ok := rebound.ActionFor(key); ok && other != action {
+		keys := slices.DeleteFunc(rebound[other], func(k string) bool { return k == key })
+		if len(keys) == 0 && len(previous) > 0 {
+			keys = append(keys, previous[0])
+		}
+		rebound[other] = keys
+	}
+	rebound[action] = []string{key}
+	return rebound
+}
+
+// Validate refuse les actions inconnues et les actions sans touche
+func (b Bindings) Validate() error {
+	var errs []error
+	for action := range b {
+		if !action.Valid() {
+			errs = append(errs, fmt.Errorf("unknown action %q", action))
+		}
+	}
+	for _, action := range actions {
+		if len(b[action]) == 0 {
+			errs = append(errs, fmt.Errorf("action %q has no key", action))
+		}
+	}
+	return errors.Join(errs...)
+}
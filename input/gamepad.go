@@ -0,0 +1,179 @@
+package input
+
+import (
+	"math"
+	"slices"
+)
+
+// PadButton nomme un bouton de la disposition standard (manette type Xbox)
+type PadButton string
+
+const (
+	PadA         PadButton = "A"
+	PadB         PadButton = "B"
+	PadX         PadButton = "X"
+	PadY         PadButton = "Y"
+	PadLB        PadButton = "LB"
+	PadRB        PadButton = "RB"
+	PadLT        PadButton = "LT"
+	PadRT        PadButton = "RT"
+	PadSelect    PadButton = "Select"
+	PadStart     PadButton = "Start"
+	PadDPadUp    PadButton = "DPadUp"
+	PadDPadDown  PadButton = "DPadDown"
+	PadDPadLeft  PadButton = "DPadLeft"
+	PadDPadRight PadButton = "DPadRight"
+)
+
+// PadState est l'état d'une manette connectée pendant un tick
+type PadState struct {
+	ID int
+	// stick gauche, chaque axe dans [-1, 1], Y positif vers le bas
+	LeftX, LeftY float64
+	Buttons      []PadButton
+}
+
+// GamepadSource fournit l'état des manettes connectées; une manette absente
+// de la liste est considérée comme débranchée
+type GamepadSource interface {
+	Gamepads() []PadState
+}
+
+// PadBindings associe chaque action aux boutons qui la déclenchent. Un bouton
+// peut servir plusieurs actions, ex. A tire en jeu et valide dans les menus.
+type PadBindings map[Action][]PadButton
+
+func DefaultPadBindings() PadBindings {
+	return PadBindings{
+		MoveUp:    {PadDPadUp},
+		MoveDown:  {PadDPadDown},
+		MoveLeft:  {PadDPadLeft},
+		MoveRight: {PadDPadRight},
+		Fire:      {PadA, PadRT},
+		Bomb:      {PadB},
+		Focus:     {PadLB, PadRB},
+		Pause:     {PadStart},
+		Confirm:   {PadA},
+		Back:      {PadB, PadSelect},
+	}
+}
+
+const DefaultDeadzone = 0.25
+
+// ApplyDeadzone annule le stick sous deadzone, puis ramène le reste sur [0, 1].
+// Le stick reste numérique: stickActions n'en garde que la direction, et le
+// joueur avance à pleine vitesse dès la sortie de la zone morte.
+func ApplyDeadzone(x, y, deadzone float64) (float64, float64) {
+	magnitude := math.Hypot(x, y)
+	if magnitude <= deadzone || deadzone >= 1 {
+		return 0, 0
+	}
+	scale := math.Min(1, (magnitude-deadzone)/(1-deadzone)) / magnitude
+	return x * scale, y * scale
+}
+
+// au-delà de sin(22.5°) une composante du stick compte comme une direction:
+// le cercle est découpé en huit secteurs égaux
+var stickSector = math.Sin(math.Pi / 8)
+
+// stickActions traduit le stick en actions de déplacement
+func stickActions(x, y, deadzone float64) []Action {
+	x, y = ApplyDeadzone(x, y, deadzone)
+	magnitude := math.Hypot(x, y)
+	if magnitude == 0 {
+		return nil
+	}
+	x, y = x/magnitude, y/magnitude
+	var actions []Action
+	if y < -stickSector {
+		actions = append(actions, MoveUp)
+	}
+	if y > stickSector {
+		actions = append(actions, MoveDown)
+	}
+	if x < -stickSector {
+		actions = append(actions, MoveLeft)
+	}
+	if x > stickSector {
+		actions = append(actions, MoveRight)
+	}
+	return actions
+}
+
+// Gamepads résout les actions de toutes les manettes, tick après tick.
+// Chaque manette garde ses actions du tick précédent pour détecter les
+// déclenchements; une manette débranchée oublie son état.
+type Gamepads struct {
+	Bindings PadBindings
+	Deadzone float64
+	previous map[int]map[Action]bool
+}
+
+func NewGamepads(bindings PadBindings, deadzone float64) *Gamepads {
+	return &Gamepads{
+		Bindings: bindings,
+		Deadzone: deadzone,
+		previous: make(map[int]map[Action]bool),
+	}
+}
+
+// Poll lit source et renvoie, dans l'ordre de Actions, les actions déclenchées
+// pendant ce tick et celles maintenues par au moins une manette
+func (g *Gamepads) Poll(source GamepadSource) (pressed, held []Action) {
+	pressedSet := make(map[Action]bool)
+	heldSet := make(map[Action]bool)
+	connected := make(map[int]bool)
+
+	for _, pad := range source.Gamepads() {
+		connected[pad.ID] = true
+		current := g.resolve(pad)
+		for action := range current {
+			heldSet[action] = true
+			if !g.previous[pad.ID][action] {
+				pressedSet[action] = true
+			}
+		}
+		g.previous[pad.ID] = current
+	}
+	for id := range g.previous {
+		if !connected[id] {
+			delete(g.previous, id)
+		}
+	}
+
+	for _, action := range actions {
+		if pressedSet[action] {
+			pressed = append(pressed, action)
+		}
+		if heldSet[action] {
+			held = append(held, action)
+		}
+	}
+	return pressed, held
+}
+
+// Connected renvoie les manettes vues au dernier Poll
+func (g *Gamepads) Connected() []int {
+	ids := make([]int, 0, len(g.previous))
+	for id := range g.previous {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func (g *Gamepads) resolve(pad PadState) map[Action]bool {
+	current := make(map[Action]bool)
+	for _, action := range stickActions(pad.LeftX, pad.LeftY, g.Deadzone) {
+		current[action] = true
+	}
+	for action, buttons := range g.Bindings {
+		for _, button := range buttons {
+			if slices.Contains(pad.Buttons, button) {
+				current[action] = true
+				break
+			}
+		}
+	}
+	return current
+}
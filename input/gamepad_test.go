@@ -0,0 +1,80 @@
+package input
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// fakePads simule des manettes branchées, une entrée par manette
+type fakePads []PadState
+
+func (f fakePads) Gamepads() []PadState {
+	return f
+}
+
+func TestApplyDeadzone(t *testing.T) {
+	if x, y := ApplyDeadzone(0.1, -0.1, 0.25); x != 0 || y != 0 {
+		t.Errorf("stick inside the deadzone should be zero, got (%v,%v)", x, y)
+	}
+	if x, _ := ApplyDeadzone(1, 0, 0.25); x != 1 {
+		t.Errorf("full tilt should stay 1, got %v", x)
+	}
+	// juste après la zone morte la valeur repart de zéro, sans saut
+	if x, _ := ApplyDeadzone(0.26, 0, 0.25); x <= 0 || x > 0.02 {
+		t.Errorf("stick just past the deadzone should be small, got %v", x)
+	}
+	// zone morte radiale: la direction est conservée
+	x, y := ApplyDeadzone(0.6, 0.6, 0.25)
+	if math.Abs(x-y) > 1e-9 {
+		t.Errorf("diagonal should keep its direction, got (%v,%v)", x, y)
+	}
+}
+
+func TestGamepadsStickAndButtons(t *testing.T) {
+	pads := NewGamepads(DefaultPadBindings(), DefaultDeadzone)
+
+	pressed, held := pads.Poll(fakePads{{ID: 0, LeftX: 0.7, LeftY: -0.7, Buttons: []PadButton{PadA}}})
+	want := []Action{MoveUp, MoveRight, Fire, Confirm}
+	if !slices.Equal(held, want) || !slices.Equal(pressed, want) {
+		t.Errorf("first tick: pressed %v, held %v, want %v", pressed, held, want)
+	}
+
+	pressed, held = pads.Poll(fakePads{{ID: 0, LeftX: 0.7, LeftY: -0.7, Buttons: []PadButton{PadA}}})
+	if len(pressed) != 0 || !slices.Equal(held, want) {
+		t.Errorf("held tick: pressed %v, held %v", pressed, held)
+	}
+
+	// un stick presque horizontal ne déclenche qu'une direction
+	_, held = pads.Poll(fakePads{{ID: 0, LeftX: -0.9, LeftY: 0.2}})
+	if !slices.Equal(held, []Action{MoveLeft}) {
+		t.Errorf("stick slightly below horizontal: held %v, want [left]", held)
+	}
+
+	_, held = pads.Poll(fakePads{{ID: 0, LeftX: 0.1, LeftY: 0.1, Buttons: []PadButton{PadDPadDown}}})
+	if !slices.Equal(held, []Action{MoveDown}) {
+		t.Errorf("stick in the deadzone with D-pad down: held %v", held)
+	}
+}
+
+func TestGamepadsHotPlug(t *testing.T) {
+	pads := NewGamepads(DefaultPadBindings(), DefaultDeadzone)
+	pads.Poll(fakePads{{ID: 0, Buttons: []PadButton{PadStart}}})
+
+	pressed, _ := pads.Poll(fakePads{{ID: 0, Buttons: []PadButton{PadStart}}, {ID: 3, Buttons: []PadButton{PadB}}})
+	if !slices.Equal(pressed, []Action{Bomb, Back}) {
+		t.Errorf("newly connected pad: pressed %v, want [bomb back]", pressed)
+	}
+	if !slices.Equal(pads.Connected(), []int{0, 3}) {
+		t.Errorf("connected pads: %v", pads.Connected())
+	}
+
+	pads.Poll(fakePads{{ID: 3}})
+	if !slices.Equal(pads.Connected(), []int{3}) {
+		t.Errorf("unplugged pad should be forgotten: %v", pads.Connected())
+	}
+	pressed, _ = pads.Poll(fakePads{{ID: 0, Buttons: []PadButton{PadStart}}, {ID: 3}})
+	if !slices.Equal(pressed, []Action{Pause}) {
+		t.Errorf("replugged pad holding Start: pressed %v, want [pause]", pressed)
+	}
+}
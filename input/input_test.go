@@ -0,0 +1,63 @@
+package input
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	if action, held := Parse(Fire.Pressed()); action != Fire || held {
+		t.Errorf("Parse(%q) = %v, %v", Fire.Pressed(), action, held)
+	}
+	if action, held := Parse(Focus.Held()); action != Focus || !held {
+		t.Errorf("Parse(%q) = %v, %v", Focus.Held(), action, held)
+	}
+	// les enregistrements d'avant les actions restent lisibles
+	if action, _ := Parse("shoot"); action != Fire {
+		t.Errorf("legacy command shoot should map to Fire, got %v", action)
+	}
+}
+
+func TestDefaultBindingsCoverEveryAction(t *testing.T) {
+	if err := DefaultBindings().Validate(); err != nil {
+		t.Errorf("default bindings should be valid: %v", err)
+	}
+	if action, ok := DefaultBindings().ActionFor("Space"); !ok || action != Fire {
+		t.Errorf("Space should fire, got %v", action)
+	}
+}
+
+func TestRebindSwapsConflictingKey(t *testing.T) {
+	bindings := DefaultBindings()
+
+	rebound := bindings.Rebind(Fire, "ArrowUp")
+
+	if !slices.Equal(rebound[Fire], []string{"ArrowUp"}) {
+		t.Errorf("Fire keys = %v, want [ArrowUp]", rebound[Fire])
+	}
+	if !slices.Equal(rebound[MoveUp], []string{"Space"}) {
+		t.Errorf("MoveUp should take the old Fire key, got %v", rebound[MoveUp])
+	}
+	if !slices.Equal(bindings[Fire], []string{"Space"}) {
+		t.Errorf("Rebind should not modify the original table, got %v", bindings[Fire])
+	}
+	if err := rebound.Validate(); err != nil {
+		t.Errorf("rebound table should stay valid: %v", err)
+	}
+
+	// une action à plusieurs touches garde les autres
+	rebound = bindings.Rebind(Bomb, "ShiftLeft")
+	if !slices.Equal(rebound[Focus], []string{"ShiftRight"}) {
+		t.Errorf("Focus keys = %v, want [ShiftRight]", rebound[Focus])
+	}
+}
+
+func TestValidateRejectsUnknownAndMissingActions(t *testing.T) {
+	bindings := DefaultBindings()
+	bindings["dash"] = []string{"D"}
+	delete(bindings, Pause)
+
+	if err := bindings.Validate(); err == nil {
+		t.Error("expected an error for an unknown action and an unbound action")
+	}
+}
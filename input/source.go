@@ -0,0 +1,117 @@
+package input
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// State est l'état des actions pendant un tick: Pressed pour celles qui
+// viennent d'être déclenchées, Held pour toutes celles maintenues
+type State struct {
+	Pressed []Action
+	Held    []Action
+}
+
+func (s State) IsPressed(action Action) bool {
+	return slices.Contains(s.Pressed, action)
+}
+
+func (s State) IsHeld(action Action) bool {
+	return slices.Contains(s.Held, action)
+}
+
+// InputSource fournit l'état des actions à chaque tick fixe, numéroté à partir de 1.
+// Implémentations: clavier et manettes (game.LiveInput), script ou
+// enregistrement rejoué (Scripted), joueur automatique (Bot).
+type InputSource interface {
+	Poll(tick uint64) State
+}
+
+// Rebindable est une source dont l'écran d'options modifie les touches
+type Rebindable interface {
+	InputSource
+	Bindings() Bindings
+	SetBindings(bindings Bindings) error
+	// CaptureNextKey suspend les actions jusqu'à la prochaine touche pressée,
+	// dont le nom est passé à fn; un nom vide signifie que la capture est annulée
+	CaptureNextKey(fn func(key string))
+}
+
+// Scripted rejoue des commandes InputEvent indexées par tick, comme celles
+// d'un script headless ou d'un enregistrement replay
+type Scripted struct {
+	script   map[uint64][]string
+	lastTick uint64
+}
+
+func NewScripted(script map[uint64][]string) *Scripted {
+	s := &Scripted{script: script}
+	for tick := range script {
+		s.lastTick = max(s.lastTick, tick)
+	}
+	return s
+}
+
+func (s *Scripted) Poll(tick uint64) State {
+	var state State
+	for _, command := range s.script[tick] {
+		action, held := Parse(command)
+		if held {
+			state.Held = append(state.Held, action)
+		} else {
+			state.Pressed = append(state.Pressed, action)
+		}
+	}
+	return state
+}
+
+// Done indique que tout le script a été joué après tick
+func (s *Scripted) Done(tick uint64) bool {
+	return tick >= s.lastTick
+}
+
+// Bot est un joueur automatique déterministe pour les runs headless et les
+// tests d'endurance: il tire dès que possible et change de direction à
+// intervalle aléatoire. Deux bots de même graine jouent la même partie.
+type Bot struct {
+	rng       *rand.Rand
+	direction []Action
+	nextTurn  uint64
+}
+
+// botFireInterval correspond au cooldown de tir du joueur, 0.2s à 60 ticks par seconde
+const botFireInterval = 12
+
+var botDirections = [][]Action{
+	nil,
+	{MoveLeft},
+	{MoveRight},
+	{MoveUp},
+	{MoveDown},
+	{MoveUp, MoveLeft},
+	{MoveUp, MoveRight},
+	{MoveDown, MoveLeft},
+	{MoveDown, MoveRight},
+}
+
+func NewBot(seed int64) *Bot {
+	return &Bot{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (b *Bot) Poll(tick uint64) State {
+	if tick >= b.nextTurn {
+		b.direction = botDirections[b.rng.Intn(len(botDirections))]
+		b.nextTurn = tick + 15 + uint64(b.rng.Intn(45))
+	}
+	// directions puis tir: l'ordre de Actions
+	state := State{Held: append(slices.Clone(b.direction), Fire)}
+	if tick%botFireInterval == 1 {
+		state.Pressed = []Action{Fire}
+	}
+	return state
+}
+
+var (
+	_ InputSource = (*Scripted)(nil)
+	_ InputSource = (*Bot)(nil)
+)
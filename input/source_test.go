@@ -0,0 +1,50 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScriptedSource(t *testing.T) {
+	source := NewScripted(map[uint64][]string{
+		2: {Fire.Pressed(), MoveLeft.Held(), Fire.Held()},
+		5: {"pause"},
+	})
+
+	if state := source.Poll(1); len(state.Pressed) != 0 || len(state.Held) != 0 {
+		t.Errorf("tick 1 should be empty, got %+v", state)
+	}
+	state := source.Poll(2)
+	if !state.IsPressed(Fire) || !state.IsHeld(MoveLeft) || !state.IsHeld(Fire) || state.IsPressed(MoveLeft) {
+		t.Errorf("tick 2: got %+v", state)
+	}
+	if !source.Poll(5).IsPressed(Pause) {
+		t.Error("legacy command pause should be pressed at tick 5")
+	}
+	if source.Done(4) || !source.Done(5) {
+		t.Error("Done should report the end of the script at tick 5")
+	}
+}
+
+func TestBotIsDeterministic(t *testing.T) {
+	first, second := NewBot(42), NewBot(42)
+	shots := 0
+	for tick := uint64(1); tick <= 600; tick++ {
+		a, b := first.Poll(tick), second.Poll(tick)
+		if !reflect.DeepEqual(a, b) {
+			t.Fatalf("bots with the same seed diverged at tick %d: %+v vs %+v", tick, a, b)
+		}
+		if !a.IsHeld(Fire) {
+			t.Fatalf("bot should always hold Fire, tick %d: %+v", tick, a)
+		}
+		if a.IsPressed(Fire) {
+			shots++
+		}
+		if a.IsHeld(MoveLeft) && a.IsHeld(MoveRight) {
+			t.Fatalf("bot should not hold opposite directions: %+v", a)
+		}
+	}
+	if shots != 600/botFireInterval {
+		t.Errorf("bot fired %d times in 600 ticks, want %d", shots, 600/botFireInterval)
+	}
+}
@@ -1,6 +1,9 @@
 package interfaces
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type EventType int
 
@@ -28,6 +31,8 @@ const (
 	EnemyRemovedFromFormation
 	FormationCreated
 	FormationDestroyed
+
+	lastEventType = FormationDestroyed
 )
 
 type Event struct {
@@ -35,12 +40,117 @@ type Event struct {
 	Data interface{}
 }
 
+// OverflowPolicy décide du sort d'un événement quand le canal d'un abonné est plein
+type OverflowPolicy int
+
+const (
+	DropNewest OverflowPolicy = iota
+	DropOldest
+	// BlockWithTimeout attend jusqu'à BlockTimeout qu'une place se libère. En
+	// distribution par tick, l'abonné lit dans la même boucle que le Flush:
+	// l'attente ne ferait que retarder la perte, l'abonnement passe donc en Grow.
+	BlockWithTimeout
+	Grow
+)
+
+const DefaultSubscriberBuffer = 1000
+
+type SubscribeConfig struct {
+	BufferSize   int
+	Overflow     OverflowPolicy
+	BlockTimeout time.Duration
+}
+
+type SubscribeOption func(cfg *SubscribeConfig)
+
+func NewSubscribeConfig(opts ...SubscribeOption) SubscribeConfig {
+	cfg := SubscribeConfig{
+		BufferSize:   DefaultSubscriberBuffer,
+		Overflow:     DropNewest,
+		BlockTimeout: 10 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+func WithBufferSize(size int) SubscribeOption {
+	return func(cfg *SubscribeConfig) {
+		cfg.BufferSize = size
+	}
+}
+
+func WithOverflow(policy OverflowPolicy) SubscribeOption {
+	return func(cfg *SubscribeConfig) {
+		cfg.Overflow = policy
+	}
+}
+
+// WithBlockTimeout active BlockWithTimeout avec le délai donné; sans effet
+// sur le délai en distribution par tick, où l'abonnement passe en Grow
+func WithBlockTimeout(timeout time.Duration) SubscribeOption {
+	return func(cfg *SubscribeConfig) {
+		cfg.Overflow = BlockWithTimeout
+		cfg.BlockTimeout = timeout
+	}
+}
+
+// GameDuration est une durée en temps de jeu, exprimée en ticks ou en secondes
+type GameDuration struct {
+	Ticks   int
+	Seconds float64
+}
+
+func Ticks(n int) GameDuration {
+	return GameDuration{Ticks: n}
+}
+
+func Seconds(s float64) GameDuration {
+	return GameDuration{Seconds: s}
+}
+
+func (d GameDuration) InTicks() bool {
+	return d.Ticks > 0
+}
+
+type TimerHandle uint64
+
+type EventStats struct {
+	Published uint64
+	Delivered uint64
+	Dropped   uint64
+	// Vetoed compte les événements bloqués par un middleware
+	Vetoed uint64
+}
+
+type SubscriberStats struct {
+	// EventType n'a de sens que pour les abonnements à un seul type
+	EventType EventType
+	Filtered  bool
+	Overflow  OverflowPolicy
+	Delivered uint64
+	Dropped   uint64
+	Queued    int
+	Backlog   int
+}
+
 type EventManagerInterface interface {
 	Initialize(ctx context.Context) error
 	Update(deltaTime float64) error
 	Run(ctx context.Context) error
 	Shutdown()
 	Publish(eventType EventType, data interface{}) error
-	Subscribe(eventType EventType) (<-chan Event, error)
+	Subscribe(eventType EventType, opts ...SubscribeOption) (<-chan Event, error)
+	SubscribeAll(opts ...SubscribeOption) (<-chan Event, error)
+	SubscribeMany(eventTypes []EventType, opts ...SubscribeOption) (<-chan Event, error)
+	SubscribeFunc(predicate func(Event) bool, opts ...SubscribeOption) (<-chan Event, error)
 	Unsubscribe(eventType EventType, ch <-chan Event) error
+	PublishAfter(delay GameDuration, eventType EventType, data interface{}) (TimerHandle, error)
+	PublishEvery(interval GameDuration, eventType EventType, data interface{}) (TimerHandle, error)
+	CancelTimer(handle TimerHandle) bool
+	PauseTimers()
+	ResumeTimers()
+	Stats() map[EventType]EventStats
+	StatsFor(ch <-chan Event) (SubscriberStats, bool)
 }
@@ -0,0 +1,126 @@
+package interfaces
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// EventTypeInfo décrit un EventType: son nom, son rôle et le type de sa charge utile
+type EventTypeInfo struct {
+	Type        EventType
+	Name        string
+	Description string
+	// Payload est nil tant qu'aucun type de charge utile n'est enregistré
+	Payload reflect.Type
+}
+
+var (
+	eventTypesMu  sync.RWMutex
+	eventTypes    = make(map[EventType]*EventTypeInfo)
+	eventTypeByID = make(map[string]EventType)
+	nextEventType = lastEventType + 1
+)
+
+func init() {
+	builtins := []struct {
+		eventType   EventType
+		name        string
+		description string
+	}{
+		{CollisionEvent, "CollisionEvent", "two entities collided"},
+		{InputEvent, "InputEvent", "player input command for this tick"},
+		{GameStateChangeEvent, "GameStateChangeEvent", "game state change request or notification"},
+		{LevelEvent, "LevelEvent", "request to advance by a number of levels"},
+		{ScoreEvent, "ScoreEvent", "points to add to the score"},
+		{ScoreReset, "ScoreReset", "score was reset"},
+		{PlayerShot, "PlayerShot", "player fired"},
+		{PlayerDamaged, "PlayerDamaged", "player took damage"},
+		{PlayerDestroyed, "PlayerDestroyed", "player health reached zero"},
+		{BulletCreated, "BulletCreated", "bullet entered the playfield"},
+		{BulletDestroyed, "BulletDestroyed", "bullet hit something or left the playfield"},
+		{EnemyCreated, "EnemyCreated", "enemy spawned"},
+		{EnemyShot, "EnemyShot", "enemy fired"},
+		{EnemyDamaged, "EnemyDamaged", "enemy took damage"},
+		{EnemyDestroyed, "EnemyDestroyed", "enemy health reached zero"},
+		{BossShot, "BossShot", "boss fired"},
+		{BossPhaseChanged, "BossPhaseChanged", "boss entered a new attack phase"},
+		{BossDamaged, "BossDamaged", "boss took damage"},
+		{BossDefeated, "BossDefeated", "boss health reached zero"},
+		{EnemyAddedToFormation, "EnemyAddedToFormation", "enemy joined a formation"},
+		{EnemyRemovedFromFormation, "EnemyRemovedFromFormation", "enemy left a formation"},
+		{FormationCreated, "FormationCreated", "formation spawned"},
+		{FormationDestroyed, "FormationDestroyed", "formation has no members left"},
+	}
+	for _, b := range builtins {
+		eventTypes[b.eventType] = &EventTypeInfo{Type: b.eventType, Name: b.name, Description: b.description}
+		eventTypeByID[b.name] = b.eventType
+	}
+}
+
+// RegisterEventType alloue un nouvel EventType, typiquement depuis un init() de package.
+// Elle panique si le nom est déjà pris.
+func RegisterEventType(name, description string) EventType {
+	eventTypesMu.Lock()
+	defer eventTypesMu.Unlock()
+	if _, exists := eventTypeByID[name]; exists {
+		panic(fmt.Sprintf("event type %q already registered", name))
+	}
+	eventType := nextEventType
+	nextEventType++
+	eventTypes[eventType] = &EventTypeInfo{Type: eventType, Name: name, Description: description}
+	eventTypeByID[name] = eventType
+	return eventType
+}
+
+// SetEventPayload fixe le type de charge utile d'un EventType enregistré
+func SetEventPayload(eventType EventType, payload reflect.Type) error {
+	eventTypesMu.Lock()
+	defer eventTypesMu.Unlock()
+	info, ok := eventTypes[eventType]
+	if !ok {
+		return fmt.Errorf("unknown event type %d", int(eventType))
+	}
+	if info.Payload != nil && info.Payload != payload {
+		return fmt.Errorf("event type %s already carries %v payloads, cannot register %v", info.Name, info.Payload, payload)
+	}
+	info.Payload = payload
+	return nil
+}
+
+func LookupEventType(eventType EventType) (EventTypeInfo, bool) {
+	eventTypesMu.RLock()
+	defer eventTypesMu.RUnlock()
+	info, ok := eventTypes[eventType]
+	if !ok {
+		return EventTypeInfo{}, false
+	}
+	return *info, true
+}
+
+func EventTypeByName(name string) (EventType, bool) {
+	eventTypesMu.RLock()
+	defer eventTypesMu.RUnlock()
+	eventType, ok := eventTypeByID[name]
+	return eventType, ok
+}
+
+// AllEventTypes renvoie tous les EventType enregistrés, dans l'ordre
+func AllEventTypes() []EventType {
+	eventTypesMu.RLock()
+	defer eventTypesMu.RUnlock()
+	all := make([]EventType, 0, len(eventTypes))
+	for eventType := range eventTypes {
+		all = append(all, eventType)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	return all
+}
+
+func (t EventType) String() string {
+	if info, ok := LookupEventType(t); ok {
+		return info.Name
+	}
+	return fmt.Sprintf("EventType(%d)", int(t))
+}
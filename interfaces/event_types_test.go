@@ -0,0 +1,73 @@
+package interfaces
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+var registeredByTests atomic.Int64
+
+// uniqueEventName évite de réenregistrer un nom dans le registre global
+// quand les tests sont relancés dans le même processus (-count=2)
+func uniqueEventName(t *testing.T) string {
+	return fmt.Sprintf("%s#%d", t.Name(), registeredByTests.Add(1))
+}
+
+func TestBuiltinEventTypeNames(t *testing.T) {
+	if got := PlayerDamaged.String(); got != "PlayerDamaged" {
+		t.Errorf("PlayerDamaged.String() = %q", got)
+	}
+	for _, eventType := range AllEventTypes() {
+		info, ok := LookupEventType(eventType)
+		if !ok || info.Name == "" || info.Description == "" {
+			t.Errorf("event type %d has no name or description: %+v", int(eventType), info)
+		}
+	}
+	if got := EventType(-1).String(); got != "EventType(-1)" {
+		t.Errorf("unknown event type String() = %q", got)
+	}
+}
+
+func TestRegisterEventType(t *testing.T) {
+	name := uniqueEventName(t)
+	custom := RegisterEventType(name, "registered by a test")
+	if custom <= lastEventType {
+		t.Fatalf("custom event type %d collides with the built-in block", int(custom))
+	}
+	if got, ok := EventTypeByName(name); !ok || got != custom {
+		t.Errorf("EventTypeByName = %v, %v", got, ok)
+	}
+	if custom.String() != name {
+		t.Errorf("String() = %q", custom.String())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a duplicate name should panic")
+		}
+	}()
+	RegisterEventType(name, "duplicate")
+}
+
+func TestSetEventPayload(t *testing.T) {
+	custom := RegisterEventType(uniqueEventName(t), "payload schema test")
+	intType := reflect.TypeOf(0)
+
+	if err := SetEventPayload(custom, intType); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetEventPayload(custom, intType); err != nil {
+		t.Errorf("re-registering the same payload type: %v", err)
+	}
+	if err := SetEventPayload(custom, reflect.TypeOf("")); err == nil {
+		t.Error("expected an error for a conflicting payload type")
+	}
+	if err := SetEventPayload(EventType(-1), intType); err == nil {
+		t.Error("expected an error for an unknown event type")
+	}
+	if info, _ := LookupEventType(custom); info.Payload != intType {
+		t.Errorf("payload = %v, want int", info.Payload)
+	}
+}
@@ -2,18 +2,67 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/event"
 	"github.com/ajkula/shmup/game"
+	"github.com/ajkula/shmup/replay"
+	"github.com/ajkula/shmup/sim"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 func main() {
-	config.Init()
+	recordPath := flag.String("record", "", "write every game event to this JSONL file")
+	replayPath := flag.String("replay", "", "replay the inputs recorded in this JSONL file")
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	cfg, err := configFlags.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	// -print-config affiche aussi une config invalide, pour voir d'où vient l'erreur
+	if configFlags.PrintConfig() {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid config:\n%v", err)
+	}
+	opts := []sim.Option{sim.WithBindingsFile(config.DefaultFile)}
+	if path := configFlags.Path(); path != "" {
+		opts = append(opts,
+			sim.WithConfigWatcher(config.NewWatcher(path, configFlags.Load)),
+			sim.WithBindingsFile(path))
+	}
+
+	if *recordPath != "" {
+		f, err := os.Create(*recordPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		opts = append(opts, sim.WithRecorder(f))
+	}
+	if *replayPath != "" {
+		source, err := replay.LoadInputSource(*replayPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, sim.WithInputSource(source))
+	}
+	// en distribution asynchrone, une entrée peut être estampillée un tick trop
+	// tard: l'enregistrement ne se rejouerait pas à l'identique
+	if *recordPath != "" || *replayPath != "" {
+		opts = append(opts, sim.WithDispatchMode(event.DispatchPerTick))
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -26,12 +75,12 @@ func main() {
 		cancel()
 	}()
 
-	g, err := game.NewGame(ctx)
+	g, err := game.NewGame(ctx, cfg, opts...)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	ebiten.SetWindowSize(config.Config.ScreenWidth, config.Config.ScreenHeight)
+	ebiten.SetWindowSize(cfg.ScreenWidth, cfg.ScreenHeight)
 	ebiten.SetWindowTitle("Shmup Game")
 
 	if err := ebiten.RunGame(g); err != nil {
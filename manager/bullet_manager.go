@@ -5,25 +5,55 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/entity"
 	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/topics"
 	"github.com/ajkula/shmup/types"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type BulletManager struct {
 	core.BaseSystem
-	bullets       []types.GameEntity
-	eventManager  interfaces.EventManagerInterface
-	mu            sync.RWMutex
-	eventChannels map[interfaces.EventType]<-chan interfaces.Event
+	bullets      []types.GameEntity
+	eventManager interfaces.EventManagerInterface
+	mu           sync.RWMutex
+	events       <-chan interfaces.Event
+	// dernière config rechargée, appliquée aussi aux balles créées ensuite
+	tuning *config.GameConfig
+	// difficulté courante des balles ennemies, le preset de la config à défaut
+	scaling func() config.DifficultyPreset
 }
 
-func NewBulletManager(eventManager interfaces.EventManagerInterface) *BulletManager {
-	return &BulletManager{
+// écart horizontal des deux balles de flanc d'un coup chargé
+const chargedShotSpread = 12.0
+
+type BulletManagerOption func(bm *BulletManager)
+
+// WithBulletConfig règle les balles du joueur sur cfg plutôt que sur le global config.Config
+func WithBulletConfig(cfg config.GameConfig) BulletManagerOption {
+	return func(bm *BulletManager) {
+		bm.tuning = &cfg
+	}
+}
+
+// WithBulletScaling règle la vitesse des balles ennemies sur scaling() à leur
+// création, typiquement LevelManager.Scaling
+func WithBulletScaling(scaling func() config.DifficultyPreset) BulletManagerOption {
+	return func(bm *BulletManager) {
+		bm.scaling = scaling
+	}
+}
+
+func NewBulletManager(eventManager interfaces.EventManagerInterface, opts ...BulletManagerOption) *BulletManager {
+	bm := &BulletManager{
 		bullets:      make([]types.GameEntity, 0),
 		eventManager: eventManager,
 	}
+	for _, opt := range opts {
+		opt(bm)
+	}
+	return bm
 }
 
 func (bm *BulletManager) Initialize(ctx context.Context) error {
@@ -36,14 +66,17 @@ func (bm *BulletManager) Initialize(ctx context.Context) error {
 	eventTypes := []interfaces.EventType{
 		interfaces.BulletCreated,
 		interfaces.BulletDestroyed,
+		interfaces.PlayerShot,
+		entity.PlayerChargedShot,
+		interfaces.EnemyShot,
+		interfaces.BossShot,
+		config.ChangeEvent,
 	}
 
-	bm.eventChannels = make(map[interfaces.EventType]<-chan interfaces.Event)
-	for _, eventType := range eventTypes {
-		bm.eventChannels[eventType], err = bm.eventManager.Subscribe(eventType)
-		if err != nil {
-			return fmt.Errorf("failedto initilize: %s", err)
-		}
+	// un BulletDestroyed perdu ou lu avant son BulletCreated laisserait une balle fantôme
+	bm.events, err = bm.eventManager.SubscribeMany(eventTypes, interfaces.WithOverflow(interfaces.Grow))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to bullet events: %w", err)
 	}
 
 	return nil
@@ -75,27 +108,43 @@ func (bm *BulletManager) Update(deltaTime float64) error {
 
 func (bm *BulletManager) gatherEvents() []interfaces.Event {
 	var events []interfaces.Event
-	for _, ch := range bm.eventChannels {
-		// Limit the number of processed events per chan to avoid infinite loop
-		for i := 0; i < 100; i++ {
-			select {
-			case evt, ok := <-ch:
-				if !ok {
-					break
-				}
-				events = append(events, evt)
-			default:
-				// nothing
+drain:
+	for {
+		select {
+		case evt, ok := <-bm.events:
+			if !ok {
+				break drain
 			}
+			events = append(events, evt)
+		default:
+			// no more events
+			break drain
 		}
 	}
 	return events
 }
 
 func (bm *BulletManager) handleEvent(evt interfaces.Event) {
+	if cfg, ok := topics.ConfigChange.Payload(evt); ok {
+		bm.applyConfig(cfg)
+		return
+	}
+	if shot, ok := entity.ChargedShotTopic.Payload(evt); ok {
+		// le PlayerShot qui accompagne le coup chargé tire déjà la balle centrale
+		bm.spawnPlayerBullet(shot.Shooter, -chargedShotSpread)
+		bm.spawnPlayerBullet(shot.Shooter, chargedShotSpread)
+		return
+	}
 	if bullet, ok := evt.Data.(types.GameEntity); ok {
 		switch evt.Type {
+		case interfaces.PlayerShot:
+			bm.spawnPlayerBullet(bullet, 0)
+		case interfaces.EnemyShot, interfaces.BossShot:
+			bm.spawnEnemyBullet(bullet)
 		case interfaces.BulletCreated:
+			if c, ok := bullet.(types.Configurable); ok && bm.tuning != nil {
+				c.ApplyConfig(*bm.tuning)
+			}
 			bm.bullets = append(bm.bullets, bullet)
 		case interfaces.BulletDestroyed:
 			for i, b := range bm.bullets {
@@ -108,7 +157,49 @@ func (bm *BulletManager) handleEvent(evt interfaces.Event) {
 	}
 }
 
-func (bm *BulletManager) Draw(screen *ebiten.Image) {
+// spawnPlayerBullet publie une balle au-dessus du centre du tireur, décalée de
+// offset; BulletCreated l'ajoute ensuite ici et à la CollisionSystem
+func (bm *BulletManager) spawnPlayerBullet(shooter types.GameEntity, offset float64) {
+	var opts []entity.Option
+	if bm.tuning != nil {
+		opts = append(opts, entity.WithConfig(*bm.tuning))
+	}
+	pos := shooter.GetPosition()
+	width, _ := shooter.GetSize()
+	bullet := entity.NewBullet(0, 0, false, bm.eventManager, opts...)
+	bulletWidth, bulletHeight := bullet.GetSize()
+	bullet.SetPosition(types.Vector2D{X: pos.X + (width-bulletWidth)/2 + offset, Y: pos.Y - bulletHeight})
+	topics.BulletCreated.Publish(bm.eventManager, bullet)
+}
+
+// spawnEnemyBullet publie une balle sous le centre du tireur, à la vitesse de la difficulté courante
+func (bm *BulletManager) spawnEnemyBullet(shooter types.GameEntity) {
+	var opts []entity.Option
+	if bm.tuning != nil {
+		opts = append(opts, entity.WithConfig(*bm.tuning))
+	}
+	if bm.scaling != nil {
+		opts = append(opts, entity.WithDifficulty(bm.scaling()))
+	}
+	pos := shooter.GetPosition()
+	width, height := shooter.GetSize()
+	bullet := entity.NewBullet(0, 0, true, bm.eventManager, opts...)
+	bulletWidth, _ := bullet.GetSize()
+	bullet.SetPosition(types.Vector2D{X: pos.X + (width-bulletWidth)/2, Y: pos.Y + height})
+	topics.BulletCreated.Publish(bm.eventManager, bullet)
+}
+
+// applyConfig suppose bm.mu déjà verrouillé
+func (bm *BulletManager) applyConfig(cfg config.GameConfig) {
+	bm.tuning = &cfg
+	for _, bullet := range bm.bullets {
+		if c, ok := bullet.(types.Configurable); ok {
+			c.ApplyConfig(cfg)
+		}
+	}
+}
+
+func (bm *BulletManager) Draw(screen types.Screen) {
 	bm.mu.RLock()
 	defer bm.mu.RUnlock()
 	for _, bullet := range bm.bullets {
@@ -133,13 +224,19 @@ func (bm *BulletManager) RemoveBullet(bullet types.GameEntity) {
 	}
 }
 
+func (bm *BulletManager) GetBulletCount() int {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+	return len(bm.bullets)
+}
+
 func (bm *BulletManager) Shutdown() {
 	bm.mu.Lock()
 	defer bm.mu.Unlock()
-	for eventType, ch := range bm.eventChannels {
-		bm.eventManager.Unsubscribe(eventType, ch)
+	if bm.events != nil {
+		bm.eventManager.Unsubscribe(interfaces.BulletCreated, bm.events)
+		bm.events = nil
 	}
-	bm.eventChannels = nil
 	bm.bullets = nil
 }
 
@@ -5,8 +5,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/entity"
 	"github.com/ajkula/shmup/interfaces"
 	"github.com/ajkula/shmup/mocks"
+	"github.com/ajkula/shmup/topics"
+	"github.com/ajkula/shmup/types"
 )
 
 func TestNewBulletManager(t *testing.T) {
@@ -36,14 +40,46 @@ func TestBulletManagerInitialize(t *testing.T) {
 	if bm.CTX != ctx {
 		t.Error("Context not set correctly")
 	}
-	if len(bm.eventChannels) != 2 {
-		t.Errorf("Expected 2 event channels, got %d", len(bm.eventChannels))
+	if bm.events == nil {
+		t.Error("Event channel not initialized")
 	}
-	if _, ok := bm.eventChannels[interfaces.BulletCreated]; !ok {
-		t.Error("BulletCreated event channel not initialized")
+}
+
+func TestBulletManagerCreatedThenDestroyedInOneTick(t *testing.T) {
+	// l'ordre de publication doit être respecté, sinon la balle reste fantôme
+	for i := 0; i < 20; i++ {
+		eventManager := mocks.NewMockEventManager()
+		bm := NewBulletManager(eventManager)
+		bm.Initialize(context.Background())
+
+		bullet := mocks.NewMockBullet(100, 100, false, eventManager)
+		topics.BulletCreated.Publish(eventManager, bullet)
+		topics.BulletDestroyed.Publish(eventManager, bullet)
+
+		if err := bm.Update(0.16); err != nil {
+			t.Fatalf("Update returned an error: %v", err)
+		}
+		if count := bm.GetBulletCount(); count != 0 {
+			t.Fatalf("Expected the destroyed bullet to be gone, got %d bullets", count)
+		}
+	}
+}
+
+func TestBulletManagerDrainsEveryEvent(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	bm := NewBulletManager(eventManager)
+	bm.Initialize(context.Background())
+
+	// plus de 100 événements d'un même type dans un seul tick
+	const bullets = 250
+	for i := 0; i < bullets; i++ {
+		topics.BulletCreated.Publish(eventManager, mocks.NewMockBullet(100, 100, false, eventManager))
 	}
-	if _, ok := bm.eventChannels[interfaces.BulletDestroyed]; !ok {
-		t.Error("BulletDestroyed event channel not initialized")
+	if err := bm.Update(0.16); err != nil {
+		t.Fatalf("Update returned an error: %v", err)
+	}
+	if count := bm.GetBulletCount(); count != bullets {
+		t.Errorf("Expected %d bullets after one Update, got %d", bullets, count)
 	}
 }
 
@@ -191,6 +227,9 @@ func TestBulletManagerRemoveBullet(t *testing.T) {
 
 	bullet := mocks.NewMockBullet(100, 100, true, eventManager)
 	bm.AddBullet(bullet)
+	if bm.GetBulletCount() != 1 {
+		t.Errorf("Expected bullet count 1, got %d", bm.GetBulletCount())
+	}
 	bm.RemoveBullet(bullet)
 
 	if len(bm.bullets) != 0 {
@@ -263,3 +302,90 @@ func TestBulletManagerConcurrency(t *testing.T) {
 		t.Errorf("Expected %d bullets, got %d", numOperations, len(bm.bullets))
 	}
 }
+
+func TestBulletManagerAppliesConfigChange(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	bm := NewBulletManager(eventManager)
+	if err := bm.Initialize(context.Background()); err != nil {
+		t.Fatalf("Failed to initialize BulletManager: %v", err)
+	}
+
+	existing := entity.NewBullet(100, 100, false, eventManager)
+	bm.AddBullet(existing)
+
+	cfg := config.Default()
+	cfg.BulletSpeed = 20
+	config.ChangeTopic.Publish(eventManager, cfg)
+	bm.Update(0)
+
+	if existing.Speed != 20 {
+		t.Errorf("Existing bullet speed: got %v, want 20", existing.Speed)
+	}
+
+	created := entity.NewBullet(100, 100, true, eventManager)
+	eventManager.Publish(interfaces.BulletCreated, created)
+	bm.Update(0)
+
+	if created.Speed != 20 {
+		t.Errorf("Bullet created after the reload: got speed %v, want 20", created.Speed)
+	}
+}
+
+// firePlayer fait tourner joueur et BulletManager ticks fois à 60 ticks par
+// seconde et renvoie le nombre de balles créées
+func firePlayer(t *testing.T, player *entity.Player, bm *BulletManager, eventManager *mocks.MockEventManager, ticks int, trigger func(tick int)) int {
+	t.Helper()
+	created, err := eventManager.Subscribe(interfaces.BulletCreated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for tick := 0; tick < ticks; tick++ {
+		trigger(tick)
+		player.Update(1.0 / 60)
+		if err := bm.Update(1.0 / 60); err != nil {
+			t.Fatalf("Update returned an error: %v", err)
+		}
+	}
+	count := len(created)
+	eventManager.Unsubscribe(interfaces.BulletCreated, created)
+	return count
+}
+
+func TestBulletManagerSpawnsPlayerBullets(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	bm := NewBulletManager(eventManager, WithBulletConfig(config.Default()))
+	if err := bm.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize returned an error: %v", err)
+	}
+	// assez bas pour que la première balle soit encore à l'écran après 60 ticks
+	player := entity.NewPlayer(types.Vector2D{X: 100, Y: 850}, eventManager)
+
+	// Blaster: 0.2s de cooldown, une balle toutes les 12 ticks
+	got := firePlayer(t, player, bm, eventManager, 60, func(int) { player.HoldTrigger() })
+	if got != 5 {
+		t.Errorf("bullets while holding fire for 1s: got %d, want 5", got)
+	}
+	if bm.GetBulletCount() != 5 {
+		t.Errorf("BulletManager should track the spawned bullets, got %d", bm.GetBulletCount())
+	}
+	for _, bullet := range bm.bullets {
+		if pos := bullet.GetPosition(); pos.Y >= player.GetPosition().Y {
+			t.Errorf("player bullet should start above the player and fly up, got %+v", pos)
+		}
+	}
+
+	// coup chargé: balle centrale de l'appui, puis trois au relâchement
+	player.Equip(entity.Charger)
+	player.ShootCooldown = 0
+	got = firePlayer(t, player, bm, eventManager, 61, func(tick int) {
+		switch {
+		case tick == 0:
+			player.PressTrigger()
+		case tick < 60:
+			player.HoldTrigger()
+		}
+	})
+	if got != 4 {
+		t.Errorf("bullets for a charged shot: got %d, want 4", got)
+	}
+}
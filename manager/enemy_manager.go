@@ -2,32 +2,70 @@ package manager
 
 import (
 	"context"
+	"math"
 	"sync"
 
+	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/entity"
 	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/topics"
 	"github.com/ajkula/shmup/types"
-	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// ennemis en jeu au-delà desquels plus rien n'apparaît
+	maxSpawnedEnemies = 8
+	// hauteur de la rangée d'apparition
+	spawnRow = 40.0
+	// répartit les apparitions sur la largeur sans hasard, pour des parties rejouables
+	spawnSpread = 0.618033988749895
 )
 
 type EnemyManager struct {
 	core.BaseSystem
-	enemies       []types.GameEntity
-	formations    []types.Formation
-	eventManager  interfaces.EventManagerInterface
-	mu            sync.RWMutex
-	eventChannels map[interfaces.EventType]<-chan interfaces.Event
+	enemies      []types.GameEntity
+	formations   []types.Formation
+	eventManager interfaces.EventManagerInterface
+	mu           sync.RWMutex
+	events       <-chan interfaces.Event
+	// dernière config rechargée, appliquée aussi aux ennemis créés ensuite
+	tuning *config.GameConfig
+	// levels règle le rythme et la difficulté des apparitions; nil n'en crée aucune
+	levels     *LevelManager
+	spawnTimer float64
+	spawned    int
+}
+
+type EnemyManagerOption func(em *EnemyManager)
+
+// WithEnemyConfig règle les ennemis créés sur cfg plutôt que sur le global config.Config
+func WithEnemyConfig(cfg config.GameConfig) EnemyManagerOption {
+	return func(em *EnemyManager) {
+		em.tuning = &cfg
+	}
 }
 
-func NewEnemyManager(eventManager interfaces.EventManagerInterface) *EnemyManager {
-	return &EnemyManager{
-		enemies:       make([]types.GameEntity, 0),
-		formations:    make([]types.Formation, 0),
-		eventManager:  eventManager,
-		eventChannels: make(map[interfaces.EventType]<-chan interfaces.Event),
+// WithSpawner fait apparaître un ennemi à chaque levels.GetSpawnInterval(),
+// créé avec levels.Scaling(): preset choisi au menu, niveau et rang
+func WithSpawner(levels *LevelManager) EnemyManagerOption {
+	return func(em *EnemyManager) {
+		em.levels = levels
 	}
 }
 
+func NewEnemyManager(eventManager interfaces.EventManagerInterface, opts ...EnemyManagerOption) *EnemyManager {
+	em := &EnemyManager{
+		enemies:      make([]types.GameEntity, 0),
+		formations:   make([]types.Formation, 0),
+		eventManager: eventManager,
+	}
+	for _, opt := range opts {
+		opt(em)
+	}
+	return em
+}
+
 func (em *EnemyManager) Initialize(ctx context.Context) error {
 	err := em.BaseSystem.Initialize(ctx)
 	if err != nil {
@@ -39,17 +77,12 @@ func (em *EnemyManager) Initialize(ctx context.Context) error {
 		interfaces.EnemyDestroyed,
 		interfaces.FormationCreated,
 		interfaces.FormationDestroyed,
+		config.ChangeEvent,
 	}
 
-	for _, eventType := range eventTypes {
-		ch, err := em.eventManager.Subscribe(eventType)
-		if err != nil {
-			return err
-		}
-		em.eventChannels[eventType] = ch
-	}
-
-	return nil
+	// un EnemyDestroyed perdu laisserait un ennemi fantôme: on ne jette rien
+	em.events, err = em.eventManager.SubscribeMany(eventTypes, interfaces.WithOverflow(interfaces.Grow))
+	return err
 }
 
 func (em *EnemyManager) Update(deltaTime float64) error {
@@ -58,48 +91,99 @@ func (em *EnemyManager) Update(deltaTime float64) error {
 		return em.CTX.Err()
 	default:
 		em.processEvents()
+		em.spawn(deltaTime)
 		return em.updateEntities(deltaTime)
 	}
 }
 
 func (em *EnemyManager) processEvents() {
-	for eventType, ch := range em.eventChannels {
-		for {
-			select {
-			case evt, ok := <-ch:
-				if !ok {
-					return
-				}
-				em.handleEvent(eventType, evt)
-			default:
-				// No more events for this type
+	for {
+		select {
+		case evt, ok := <-em.events:
+			if !ok {
 				return
 			}
+			em.handleEvent(evt)
+		default:
+			// No more events
+			return
 		}
 	}
 }
 
-func (em *EnemyManager) handleEvent(eventType interfaces.EventType, evt interfaces.Event) {
-	switch eventType {
+func (em *EnemyManager) handleEvent(evt interfaces.Event) {
+	switch evt.Type {
 	case interfaces.EnemyCreated:
-		if enemy, ok := evt.Data.(types.GameEntity); ok {
+		if enemy, ok := topics.EnemyCreated.Payload(evt); ok {
 			em.AddEnemy(enemy)
 		}
 	case interfaces.EnemyDestroyed:
-		if enemy, ok := evt.Data.(types.GameEntity); ok {
+		if enemy, ok := topics.EnemyDestroyed.Payload(evt); ok {
 			em.RemoveEnemy(enemy)
 		}
 	case interfaces.FormationCreated:
-		if formation, ok := evt.Data.(types.Formation); ok {
+		if formation, ok := topics.FormationCreated.Payload(evt); ok {
 			em.AddFormation(formation)
 		}
 	case interfaces.FormationDestroyed:
-		if formation, ok := evt.Data.(types.Formation); ok {
+		if formation, ok := topics.FormationDestroyed.Payload(evt); ok {
 			em.RemoveFormation(formation)
 		}
+	case config.ChangeEvent:
+		if cfg, ok := topics.ConfigChange.Payload(evt); ok {
+			em.applyConfig(cfg)
+		}
 	}
 }
 
+func (em *EnemyManager) applyConfig(cfg config.GameConfig) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	em.tuning = &cfg
+	for _, enemy := range em.enemies {
+		if c, ok := enemy.(types.Configurable); ok {
+			c.ApplyConfig(cfg)
+		}
+	}
+	for _, formation := range em.formations {
+		for _, member := range formation.GetEntities() {
+			if c, ok := member.(types.Configurable); ok {
+				c.ApplyConfig(cfg)
+			}
+		}
+	}
+}
+
+// spawn publie un EnemyCreated par intervalle; l'ennemi est ajouté à sa réception,
+// comme ceux créés ailleurs, et devient alors une cible pour la CollisionSystem
+func (em *EnemyManager) spawn(deltaTime float64) {
+	if em.levels == nil {
+		return
+	}
+	em.spawnTimer += deltaTime
+	if em.spawnTimer < em.levels.GetSpawnInterval() {
+		return
+	}
+	em.spawnTimer = 0
+	if em.GetEnemyCount() >= maxSpawnedEnemies {
+		return
+	}
+
+	em.mu.RLock()
+	cfg := config.Config
+	if em.tuning != nil {
+		cfg = *em.tuning
+	}
+	em.mu.RUnlock()
+
+	enemy := entity.NewEnemy(types.Vector2D{}, em.eventManager, entity.WithConfig(cfg), entity.WithDifficulty(em.levels.Scaling()))
+	width, _ := enemy.GetSize()
+	column := math.Mod(float64(em.spawned)*spawnSpread, 1)
+	enemy.SetPosition(types.Vector2D{X: column * (float64(cfg.ScreenWidth) - width), Y: spawnRow})
+	em.spawned++
+	topics.EnemyCreated.Publish(em.eventManager, enemy)
+}
+
 func (em *EnemyManager) updateEntities(deltaTime float64) error {
 	em.mu.Lock()
 	defer em.mu.Unlock()
@@ -123,7 +207,7 @@ func (em *EnemyManager) updateEntities(deltaTime float64) error {
 	return nil
 }
 
-func (em *EnemyManager) Draw(screen *ebiten.Image) {
+func (em *EnemyManager) Draw(screen types.Screen) {
 	em.mu.RLock()
 	defer em.mu.RUnlock()
 	for _, enemy := range em.enemies {
@@ -134,6 +218,9 @@ func (em *EnemyManager) Draw(screen *ebiten.Image) {
 func (em *EnemyManager) AddEnemy(enemy types.GameEntity) {
 	em.mu.Lock()
 	defer em.mu.Unlock()
+	if c, ok := enemy.(types.Configurable); ok && em.tuning != nil {
+		c.ApplyConfig(*em.tuning)
+	}
 	em.enemies = append(em.enemies, enemy)
 }
 
@@ -148,6 +235,12 @@ func (em *EnemyManager) RemoveEnemy(enemy types.GameEntity) {
 	}
 }
 
+func (em *EnemyManager) GetEnemyCount() int {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	return len(em.enemies)
+}
+
 func (em *EnemyManager) AddFormation(formation types.Formation) {
 	em.mu.Lock()
 	defer em.mu.Unlock()
@@ -165,13 +258,13 @@ func (em *EnemyManager) RemoveFormation(formation types.Formation) {
 	}
 }
 
+// Shutdown s'appelle une fois la boucle arrêtée, quand plus aucun Update ni
+// AddEnemy ne tourne: elle ne prend pas em.mu, que l'appelant peut détenir
 func (em *EnemyManager) Shutdown() {
-	em.mu.Lock()
-	defer em.mu.Unlock()
-	for eventType, ch := range em.eventChannels {
-		em.eventManager.Unsubscribe(eventType, ch)
+	if em.events != nil {
+		em.eventManager.Unsubscribe(interfaces.EnemyCreated, em.events)
+		em.events = nil
 	}
-	em.eventChannels = nil
 	em.enemies = nil
 	em.formations = nil
 }
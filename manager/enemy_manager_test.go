@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ajkula/shmup/mocks"
+	"github.com/ajkula/shmup/topics"
 )
 
 func TestNewEnemyManager(t *testing.T) {
@@ -92,6 +93,9 @@ func TestEnemyManagerAddRemoveEnemy(t *testing.T) {
 	if len(em.enemies) != 1 {
 		t.Error("Enemy not added")
 	}
+	if em.GetEnemyCount() != 1 {
+		t.Errorf("Expected enemy count 1, got %d", em.GetEnemyCount())
+	}
 
 	em.RemoveEnemy(enemy)
 	if len(em.enemies) != 0 {
@@ -99,6 +103,26 @@ func TestEnemyManagerAddRemoveEnemy(t *testing.T) {
 	}
 }
 
+func TestEnemyManagerCreatedThenDestroyedInOneTick(t *testing.T) {
+	// l'ordre de publication doit être respecté, sinon l'ennemi reste fantôme
+	for i := 0; i < 20; i++ {
+		eventManager := mocks.NewMockEventManager()
+		em := NewEnemyManager(eventManager)
+		em.Initialize(context.Background())
+
+		enemy := &mocks.MockEnemy{Alive: true}
+		topics.EnemyCreated.Publish(eventManager, enemy)
+		topics.EnemyDestroyed.Publish(eventManager, enemy)
+
+		if err := em.Update(0.16); err != nil {
+			t.Fatalf("Update returned an error: %v", err)
+		}
+		if count := em.GetEnemyCount(); count != 0 {
+			t.Fatalf("Expected the destroyed enemy to be gone, got %d enemies", count)
+		}
+	}
+}
+
 func TestEnemyManagerAddRemoveFormation(t *testing.T) {
 	em := NewEnemyManager(mocks.NewMockEventManager())
 	formation := &mocks.MockFormation{}
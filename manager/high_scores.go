@@ -0,0 +1,64 @@
+package manager
+
+import (
+	"sort"
+	"sync"
+)
+
+const DefaultHighScoreCapacity = 10
+
+type HighScoreEntry struct {
+	Name  string
+	Score int
+	Level int
+}
+
+// HighScoreTable garde les meilleurs scores, du plus haut au plus bas
+type HighScoreTable struct {
+	entries  []HighScoreEntry
+	capacity int
+	mu       sync.RWMutex
+}
+
+func NewHighScoreTable(capacity int) *HighScoreTable {
+	return &HighScoreTable{
+		entries:  make([]HighScoreEntry, 0, capacity),
+		capacity: capacity,
+	}
+}
+
+// Qualifies indique si score entrerait dans la table
+func (t *HighScoreTable) Qualifies(score int) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if score <= 0 || t.capacity <= 0 {
+		return false
+	}
+	return len(t.entries) < t.capacity || score > t.entries[len(t.entries)-1].Score
+}
+
+// Insert ajoute une entrée et renvoie son rang (0 = premier), ou -1 si elle ne qualifie pas.
+// À score égal, l'entrée la plus ancienne reste devant.
+func (t *HighScoreTable) Insert(entry HighScoreEntry) int {
+	if !t.Qualifies(entry.Score) {
+		return -1
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rank := sort.Search(len(t.entries), func(i int) bool {
+		return t.entries[i].Score < entry.Score
+	})
+	t.entries = append(t.entries, HighScoreEntry{})
+	copy(t.entries[rank+1:], t.entries[rank:])
+	t.entries[rank] = entry
+	if len(t.entries) > t.capacity {
+		t.entries = t.entries[:t.capacity]
+	}
+	return rank
+}
+
+func (t *HighScoreTable) Entries() []HighScoreEntry {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]HighScoreEntry{}, t.entries...)
+}
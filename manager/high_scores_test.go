@@ -0,0 +1,35 @@
+package manager
+
+import "testing"
+
+func TestHighScoreTableOrderAndCapacity(t *testing.T) {
+	table := NewHighScoreTable(3)
+
+	if table.Qualifies(0) {
+		t.Error("A zero score should never qualify")
+	}
+	table.Insert(HighScoreEntry{Name: "AAA", Score: 100})
+	table.Insert(HighScoreEntry{Name: "BBB", Score: 300})
+	table.Insert(HighScoreEntry{Name: "CCC", Score: 200})
+
+	if table.Qualifies(100) {
+		t.Error("A score equal to the lowest entry of a full table should not qualify")
+	}
+	if rank := table.Insert(HighScoreEntry{Name: "DDD", Score: 200}); rank != 2 {
+		t.Errorf("Insert rank: got %d, want 2", rank)
+	}
+
+	entries := table.Entries()
+	expected := []string{"BBB", "CCC", "DDD"}
+	if len(entries) != len(expected) {
+		t.Fatalf("Entries: got %d, want %d", len(entries), len(expected))
+	}
+	for i, name := range expected {
+		if entries[i].Name != name {
+			t.Errorf("Entry %d: got %s, want %s", i, entries[i].Name, name)
+		}
+	}
+	if rank := table.Insert(HighScoreEntry{Name: "EEE", Score: 50}); rank != -1 {
+		t.Errorf("Non qualifying Insert rank: got %d, want -1", rank)
+	}
+}
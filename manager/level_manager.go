@@ -5,25 +5,41 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/event"
 	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/topics"
+)
+
+// LevelEvent demande d'avancer d'un nombre de niveaux, LevelChanged annonce
+// le niveau courant qui en résulte
+var (
+	LevelChanged      = interfaces.RegisterEventType("LevelChanged", "current level after an advance")
+	LevelChangedTopic = event.NewTopic[int](LevelChanged)
 )
 
 type LevelManager struct {
 	core.BaseSystem
 	currentLevel  int
 	difficulty    float64
+	spawnInterval float64
+	bossThreshold int
+	preset        config.DifficultyPreset
+	rank          float64
 	eventManager  interfaces.EventManagerInterface
 	mu            sync.RWMutex
-	eventChannels map[interfaces.EventType]<-chan interfaces.Event
+	events        <-chan interfaces.Event
 }
 
 func NewLevelManager(eventManager interfaces.EventManagerInterface) *LevelManager {
 	return &LevelManager{
 		currentLevel:  1,
 		difficulty:    1.0,
+		spawnInterval: config.Config.EnemySpawnInterval,
+		bossThreshold: config.Config.BossThreshold,
+		preset:        config.Config.DifficultyPreset(),
 		eventManager:  eventManager,
-		eventChannels: make(map[interfaces.EventType]<-chan interfaces.Event),
 	}
 }
 
@@ -33,16 +49,22 @@ func (lm *LevelManager) Initialize(ctx context.Context) error {
 		return err
 	}
 
+	cfg := config.FromContext(ctx)
+	lm.mu.Lock()
+	lm.spawnInterval = cfg.EnemySpawnInterval
+	lm.bossThreshold = cfg.BossThreshold
+	lm.preset = cfg.DifficultyPreset()
+	lm.mu.Unlock()
+
 	eventTypes := []interfaces.EventType{
 		interfaces.LevelEvent,
+		config.ChangeEvent,
+		RankChanged,
 	}
 
-	for _, eventType := range eventTypes {
-		ch, err := lm.eventManager.Subscribe(eventType)
-		if err != nil {
-			return fmt.Errorf("failed to subscribe to event type %v: %w", eventType, err)
-		}
-		lm.eventChannels[eventType] = ch
+	lm.events, err = lm.eventManager.SubscribeMany(eventTypes, interfaces.WithOverflow(interfaces.Grow))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to level events: %w", err)
 	}
 
 	return nil
@@ -59,28 +81,39 @@ func (lm *LevelManager) Update(deltaTime float64) error {
 }
 
 func (lm *LevelManager) processEvents() {
-	for eventType, ch := range lm.eventChannels {
-		for {
-			select {
-			case evt, ok := <-ch:
-				if !ok {
-					return
-				}
-				lm.handleEvent(eventType, evt)
-			default:
-				// finished
+	for {
+		select {
+		case evt, ok := <-lm.events:
+			if !ok {
 				return
 			}
+			lm.handleEvent(evt)
+		default:
+			// finished
+			return
 		}
 	}
 }
 
-func (lm *LevelManager) handleEvent(eventType interfaces.EventType, evt interfaces.Event) {
-	switch eventType {
+func (lm *LevelManager) handleEvent(evt interfaces.Event) {
+	switch evt.Type {
 	case interfaces.LevelEvent:
-		if levelChange, ok := evt.Data.(int); ok {
+		if levelChange, ok := topics.Level.Payload(evt); ok {
 			lm.AdvanceLevel(levelChange)
 		}
+	case config.ChangeEvent:
+		if cfg, ok := topics.ConfigChange.Payload(evt); ok {
+			lm.mu.Lock()
+			lm.spawnInterval = cfg.EnemySpawnInterval
+			lm.bossThreshold = cfg.BossThreshold
+			lm.mu.Unlock()
+		}
+	case RankChanged:
+		if change, ok := RankTopic.Payload(evt); ok {
+			lm.mu.Lock()
+			lm.rank = change.Rank
+			lm.mu.Unlock()
+		}
 	}
 }
 
@@ -89,10 +122,13 @@ func (lm *LevelManager) AdvanceLevel(levels int) {
 	defer lm.mu.Unlock()
 	lm.currentLevel += levels
 	lm.difficulty += float64(levels) * 0.1
-	lm.eventManager.Publish(interfaces.LevelEvent, lm.currentLevel)
+	LevelChangedTopic.Publish(lm.eventManager, lm.currentLevel)
 }
 
+// GetLevel applique d'abord les demandes déjà reçues: le résumé de fin de
+// partie compte un LevelEvent publié au dernier tick
 func (lm *LevelManager) GetLevel() int {
+	lm.processEvents()
 	lm.mu.Lock()
 	defer lm.mu.Unlock()
 	return lm.currentLevel
@@ -104,13 +140,59 @@ func (lm *LevelManager) GetDifficulty() float64 {
 	return lm.difficulty
 }
 
+// GetSpawnInterval renvoie le délai entre deux apparitions d'ennemis, en secondes,
+// raccourci par le preset, le niveau et le rang
+func (lm *LevelManager) GetSpawnInterval() float64 {
+	return lm.spawnIntervalBase() * lm.Scaling().SpawnInterval
+}
+
+func (lm *LevelManager) spawnIntervalBase() float64 {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+	return lm.spawnInterval
+}
+
+// SetDifficulty change le preset, appliqué au début d'une partie
+func (lm *LevelManager) SetDifficulty(preset config.DifficultyPreset) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	lm.preset = preset
+}
+
+// Scaling combine le preset, la difficulté du niveau courant et le rang,
+// à passer aux entités créées via entity.WithDifficulty
+func (lm *LevelManager) Scaling() config.DifficultyPreset {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+	scaled := lm.preset
+	scaled.EnemyHealth *= lm.difficulty
+	scaled.FireCooldown /= lm.difficulty
+	scaled.SpawnInterval /= lm.difficulty
+	return ApplyRank(scaled, lm.rank)
+}
+
+func (lm *LevelManager) GetBossThreshold() int {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+	return lm.bossThreshold
+}
+
+// Reset ramène au premier niveau pour une nouvelle partie
+func (lm *LevelManager) Reset() {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	lm.currentLevel = 1
+	lm.difficulty = 1.0
+	lm.rank = MinRank
+}
+
 func (lm *LevelManager) Shutdown() {
 	lm.mu.Lock()
 	defer lm.mu.Unlock()
-	for eventType, ch := range lm.eventChannels {
-		lm.eventManager.Unsubscribe(eventType, ch)
+	if lm.events != nil {
+		lm.eventManager.Unsubscribe(interfaces.LevelEvent, lm.events)
+		lm.events = nil
 	}
-	lm.eventChannels = nil
 	lm.currentLevel = 1
 	lm.difficulty = 1.0
 }
@@ -2,10 +2,12 @@ package manager
 
 import (
 	"context"
+	"math"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/interfaces"
 	"github.com/ajkula/shmup/mocks"
 )
@@ -37,8 +39,8 @@ func TestLevelManagerInitialize(t *testing.T) {
 	if lm.CTX != ctx {
 		t.Error("Context not set correctly")
 	}
-	if len(lm.eventChannels) != 1 {
-		t.Errorf("Expected 1 event channel, got %d", len(lm.eventChannels))
+	if lm.events == nil {
+		t.Error("Event channel not initialized")
 	}
 }
 
@@ -81,8 +83,8 @@ func TestLevelManagerAdvanceLevel(t *testing.T) {
 	}
 
 	events := eventManager.GetPublishedEvents()
-	if len(events) != 1 || events[0].Type != interfaces.LevelEvent {
-		t.Error("Expected LevelEvent to be published")
+	if len(events) != 1 || events[0].Type != LevelChanged {
+		t.Error("Expected LevelChanged to be published")
 	}
 }
 
@@ -103,8 +105,8 @@ func TestLevelManagerShutdown(t *testing.T) {
 		t.Errorf("Expected difficulty to be reset to 1.0, got %f", lm.GetDifficulty())
 	}
 
-	if lm.eventChannels != nil {
-		t.Error("Event channels should be nil after shutdown")
+	if lm.events != nil {
+		t.Error("Event channel should be nil after shutdown")
 	}
 }
 
@@ -150,3 +152,62 @@ func TestLevelManagerConcurrency(t *testing.T) {
 
 	lm.Shutdown()
 }
+
+func TestLevelManagerAppliesConfigChange(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	lm := NewLevelManager(eventManager)
+	lm.Initialize(context.Background())
+
+	cfg := config.Default()
+	cfg.EnemySpawnInterval = 0.5
+	cfg.BossThreshold = 120
+	config.ChangeTopic.Publish(eventManager, cfg)
+	lm.Update(0)
+
+	if lm.GetSpawnInterval() != 0.5 {
+		t.Errorf("Spawn interval: got %v, want 0.5", lm.GetSpawnInterval())
+	}
+	if lm.GetBossThreshold() != 120 {
+		t.Errorf("Boss threshold: got %v, want 120", lm.GetBossThreshold())
+	}
+}
+
+func TestLevelManagerScalingCombinesPresetAndLevel(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	lm := NewLevelManager(eventManager)
+	cfg := config.Default()
+	cfg.Difficulty = "Hard"
+	lm.Initialize(config.WithContext(context.Background(), cfg))
+
+	hard, _ := config.LookupDifficulty("Hard")
+	if got, want := lm.GetSpawnInterval(), cfg.EnemySpawnInterval*hard.SpawnInterval; got != want {
+		t.Errorf("Spawn interval on Hard: got %v, want %v", got, want)
+	}
+
+	lm.AdvanceLevel(1)
+	scaling := lm.Scaling()
+	if want := hard.EnemyHealth * 1.1; math.Abs(scaling.EnemyHealth-want) > 1e-9 {
+		t.Errorf("Enemy health scale at level 2: got %v, want %v", scaling.EnemyHealth, want)
+	}
+	if scaling.ScoreMultiplier != hard.ScoreMultiplier {
+		t.Errorf("Score multiplier should not depend on level: got %v", scaling.ScoreMultiplier)
+	}
+}
+
+func TestLevelManagerScalingFollowsRank(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	lm := NewLevelManager(eventManager)
+	lm.Initialize(context.Background())
+	base := lm.Scaling()
+
+	RankTopic.Publish(eventManager, RankChange{Previous: MinRank, Rank: MaxRank})
+	lm.Update(0)
+
+	scaling := lm.Scaling()
+	if scaling.FireCooldown >= base.FireCooldown || scaling.BulletSpeed <= base.BulletSpeed {
+		t.Errorf("Max rank should fire faster and quicker bullets: base %+v, got %+v", base, scaling)
+	}
+	if lm.GetSpawnInterval() >= config.Default().EnemySpawnInterval {
+		t.Errorf("Max rank should spawn more often, got interval %v", lm.GetSpawnInterval())
+	}
+}
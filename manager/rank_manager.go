@@ -0,0 +1,184 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/event"
+	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/topics"
+)
+
+// bornes du rang: 0 au début d'une partie, 1 pour un joueur qui ne se fait jamais toucher
+const (
+	MinRank = 0.0
+	MaxRank = 1.0
+)
+
+// variations du rang par événement
+const (
+	rankPerShot        = 0.0005
+	rankPerKill        = 0.01
+	rankPerScorePoint  = 0.00005
+	rankPerDamageTaken = -0.1
+)
+
+// RankChange décrit une variation du rang, publiée au plus une fois par tick
+type RankChange struct {
+	Previous float64
+	Rank     float64
+}
+
+var (
+	RankChanged = interfaces.RegisterEventType("RankChanged", "dynamic rank moved after player performance")
+	RankTopic   = event.NewTopic[RankChange](RankChanged)
+)
+
+// ApplyRank durcit un preset selon le rang: au rang max les ennemis tirent
+// deux fois plus souvent, leurs balles vont 50% plus vite et ils apparaissent
+// 40% plus souvent
+func ApplyRank(preset config.DifficultyPreset, rank float64) config.DifficultyPreset {
+	rank = clampRank(rank)
+	preset.FireCooldown *= 1 - 0.5*rank
+	preset.BulletSpeed *= 1 + 0.5*rank
+	preset.SpawnInterval *= 1 - 0.4*rank
+	return preset
+}
+
+func clampRank(rank float64) float64 {
+	return math.Max(MinRank, math.Min(MaxRank, rank))
+}
+
+type RankManager struct {
+	core.BaseSystem
+	rank         float64
+	published    float64
+	eventManager interfaces.EventManagerInterface
+	mu           sync.RWMutex
+	events       <-chan interfaces.Event
+}
+
+func NewRankManager(eventManager interfaces.EventManagerInterface) *RankManager {
+	return &RankManager{
+		rank:         MinRank,
+		published:    MinRank,
+		eventManager: eventManager,
+	}
+}
+
+func (rm *RankManager) Initialize(ctx context.Context) error {
+	err := rm.BaseSystem.Initialize(ctx)
+	if err != nil {
+		return err
+	}
+
+	eventTypes := []interfaces.EventType{
+		interfaces.PlayerShot,
+		interfaces.EnemyDestroyed,
+		interfaces.PlayerDamaged,
+		interfaces.ScoreEvent,
+	}
+
+	rm.events, err = rm.eventManager.SubscribeMany(eventTypes)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to rank events: %w", err)
+	}
+
+	return nil
+}
+
+func (rm *RankManager) Update(deltaTime float64) error {
+	select {
+	case <-rm.CTX.Done():
+		return rm.CTX.Err()
+	default:
+		rm.processEvents()
+		rm.publishChange()
+		return nil
+	}
+}
+
+// processEvents additionne les variations du tick et ne borne qu'une fois:
+// un tir et un coup reçu au même tick donnent le même rang dans les deux ordres
+func (rm *RankManager) processEvents() {
+	delta := 0.0
+	for {
+		select {
+		case evt, ok := <-rm.events:
+			if !ok {
+				rm.adjust(delta)
+				return
+			}
+			delta += rankDelta(evt)
+		default:
+			rm.adjust(delta)
+			return
+		}
+	}
+}
+
+func rankDelta(evt interfaces.Event) float64 {
+	switch evt.Type {
+	case interfaces.PlayerShot:
+		return rankPerShot
+	case interfaces.EnemyDestroyed:
+		return rankPerKill
+	case interfaces.PlayerDamaged:
+		return rankPerDamageTaken
+	case interfaces.ScoreEvent:
+		// les remises à zéro du score publient 0 et ne comptent pas
+		if points, ok := topics.Score.Payload(evt); ok && points > 0 {
+			return float64(points) * rankPerScorePoint
+		}
+	}
+	return 0
+}
+
+func (rm *RankManager) adjust(delta float64) {
+	if delta == 0 {
+		return
+	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.rank = clampRank(rm.rank + delta)
+}
+
+func (rm *RankManager) publishChange() {
+	rm.mu.Lock()
+	change := RankChange{Previous: rm.published, Rank: rm.rank}
+	rm.published = rm.rank
+	rm.mu.Unlock()
+
+	if change.Rank != change.Previous {
+		RankTopic.Publish(rm.eventManager, change)
+	}
+}
+
+// GetRank renvoie le rang courant, entre MinRank et MaxRank
+func (rm *RankManager) GetRank() float64 {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	return rm.rank
+}
+
+// Reset ramène le rang au minimum pour une nouvelle partie
+func (rm *RankManager) Reset() {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.rank = MinRank
+}
+
+func (rm *RankManager) Shutdown() {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if rm.events != nil {
+		rm.eventManager.Unsubscribe(interfaces.PlayerShot, rm.events)
+		rm.events = nil
+	}
+}
+
+var _ core.System = (*RankManager)(nil)
@@ -0,0 +1,167 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/entity"
+	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/mocks"
+	"github.com/ajkula/shmup/topics"
+	"github.com/ajkula/shmup/types"
+)
+
+func TestRankManagerTracksPerformance(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	rm := NewRankManager(eventManager)
+	if err := rm.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize returned an error: %v", err)
+	}
+	player := entity.NewPlayer(types.Vector2D{}, eventManager)
+	enemy := entity.NewEnemy(types.Vector2D{}, eventManager)
+
+	for i := 0; i < 10; i++ {
+		topics.PlayerShot.Publish(eventManager, player)
+		topics.EnemyDestroyed.Publish(eventManager, enemy)
+	}
+	topics.Score.Publish(eventManager, 1000)
+	rm.Update(0.16)
+
+	raised := rm.GetRank()
+	if raised <= MinRank {
+		t.Fatalf("Rank should rise with kills, shots and score, got %v", raised)
+	}
+
+	topics.PlayerDamaged.Publish(eventManager, player)
+	rm.Update(0.16)
+	if rm.GetRank() >= raised {
+		t.Errorf("Rank should drop when the player is hit: %v then %v", raised, rm.GetRank())
+	}
+
+	for i := 0; i < 20; i++ {
+		topics.PlayerDamaged.Publish(eventManager, player)
+	}
+	rm.Update(0.16)
+	if rm.GetRank() != MinRank {
+		t.Errorf("Rank should be clamped to %v, got %v", MinRank, rm.GetRank())
+	}
+}
+
+func TestRankManagerTickIsOrderIndependent(t *testing.T) {
+	rankAfter := func(events func(interfaces.EventManagerInterface, types.GameEntity, types.GameEntity)) float64 {
+		eventManager := mocks.NewMockEventManager()
+		rm := NewRankManager(eventManager)
+		rm.Initialize(context.Background())
+		player := entity.NewPlayer(types.Vector2D{}, eventManager)
+		enemy := entity.NewEnemy(types.Vector2D{}, eventManager)
+
+		// rang de départ juste sous un coup reçu
+		for i := 0; i < 5; i++ {
+			topics.EnemyDestroyed.Publish(eventManager, enemy)
+		}
+		rm.Update(0.16)
+
+		events(eventManager, player, enemy)
+		rm.Update(0.16)
+		return rm.GetRank()
+	}
+
+	hitFirst := rankAfter(func(em interfaces.EventManagerInterface, player, enemy types.GameEntity) {
+		topics.PlayerDamaged.Publish(em, player)
+		topics.EnemyDestroyed.Publish(em, enemy)
+	})
+	killFirst := rankAfter(func(em interfaces.EventManagerInterface, player, enemy types.GameEntity) {
+		topics.EnemyDestroyed.Publish(em, enemy)
+		topics.PlayerDamaged.Publish(em, player)
+	})
+	if hitFirst != killFirst {
+		t.Errorf("Same tick should give the same rank in any order: %v vs %v", hitFirst, killFirst)
+	}
+	if hitFirst != MinRank {
+		t.Errorf("A hit and a kill from %v should clamp to %v, got %v", 5*rankPerKill, MinRank, hitFirst)
+	}
+}
+
+func TestRankManagerPublishesChanges(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	rm := NewRankManager(eventManager)
+	rm.Initialize(context.Background())
+	changes, _ := eventManager.Subscribe(RankChanged)
+
+	rm.Update(0.16)
+	if len(changes) != 0 {
+		t.Fatal("No rank event expected while the rank is unchanged")
+	}
+
+	topics.EnemyDestroyed.Publish(eventManager, entity.NewEnemy(types.Vector2D{}, eventManager))
+	topics.Score.Publish(eventManager, 100000)
+	rm.Update(0.16)
+
+	if len(changes) != 1 {
+		t.Fatalf("Expected one rank event per tick, got %d", len(changes))
+	}
+	change, ok := RankTopic.Payload(<-changes)
+	if !ok {
+		t.Fatal("Rank event should carry a RankChange")
+	}
+	if change.Previous != MinRank || change.Rank != MaxRank {
+		t.Errorf("Rank change: got %+v, want %v to %v", change, MinRank, MaxRank)
+	}
+}
+
+func TestApplyRank(t *testing.T) {
+	normal, _ := config.LookupDifficulty("Normal")
+	preset := ApplyRank(normal, MaxRank)
+	if preset.FireCooldown != 0.5 || preset.BulletSpeed != 1.5 {
+		t.Errorf("ApplyRank at max rank: got %+v", preset)
+	}
+	if clamped := ApplyRank(normal, 5); clamped != preset {
+		t.Errorf("ApplyRank should clamp the rank: got %+v", clamped)
+	}
+}
+
+// enemyPressure fait tourner apparitions et tirs ennemis dix secondes au rang
+// donné et renvoie le nombre de tirs et la vitesse d'une balle ennemie
+func enemyPressure(t *testing.T, rank float64) (int, float64) {
+	t.Helper()
+	eventManager := mocks.NewMockEventManager()
+	ctx := config.WithContext(context.Background(), config.Default())
+	levels := NewLevelManager(eventManager)
+	levels.Initialize(ctx)
+	RankTopic.Publish(eventManager, RankChange{Previous: MinRank, Rank: rank})
+	levels.Update(0)
+
+	enemies := NewEnemyManager(eventManager, WithEnemyConfig(config.Default()), WithSpawner(levels))
+	bullets := NewBulletManager(eventManager, WithBulletConfig(config.Default()), WithBulletScaling(levels.Scaling))
+	if err := enemies.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := bullets.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+	shots, _ := eventManager.Subscribe(interfaces.EnemyShot)
+	created, _ := eventManager.Subscribe(interfaces.BulletCreated)
+
+	for tick := 0; tick < 600; tick++ {
+		enemies.Update(1.0 / 60)
+		bullets.Update(1.0 / 60)
+	}
+	if len(created) == 0 {
+		t.Fatalf("rank %v: enemies should have fired bullets", rank)
+	}
+	bullet := (<-created).Data.(*entity.Bullet)
+	return len(shots), bullet.Speed
+}
+
+func TestRankSpeedsUpEnemyFire(t *testing.T) {
+	calmShots, calmSpeed := enemyPressure(t, MinRank)
+	hotShots, hotSpeed := enemyPressure(t, MaxRank)
+
+	if hotShots <= calmShots {
+		t.Errorf("Enemies should fire more at max rank: %d shots vs %d", hotShots, calmShots)
+	}
+	if hotSpeed <= calmSpeed {
+		t.Errorf("Enemy bullets should be faster at max rank: %v vs %v", hotSpeed, calmSpeed)
+	}
+}
@@ -0,0 +1,129 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/interfaces"
+)
+
+// RunStats résume les actions du joueur depuis le début de la partie
+type RunStats struct {
+	ShotsFired     int
+	Hits           int
+	EnemiesKilled  int
+	BossesDefeated int
+}
+
+// Accuracy renvoie la part des tirs ayant touché, entre 0 et 1
+func (s RunStats) Accuracy() float64 {
+	if s.ShotsFired == 0 {
+		return 0
+	}
+	accuracy := float64(s.Hits) / float64(s.ShotsFired)
+	if accuracy > 1 {
+		return 1
+	}
+	return accuracy
+}
+
+type RunStatsManager struct {
+	core.BaseSystem
+	stats        RunStats
+	eventManager interfaces.EventManagerInterface
+	mu           sync.RWMutex
+	events       <-chan interfaces.Event
+}
+
+func NewRunStatsManager(eventManager interfaces.EventManagerInterface) *RunStatsManager {
+	return &RunStatsManager{
+		eventManager: eventManager,
+	}
+}
+
+func (rm *RunStatsManager) Initialize(ctx context.Context) error {
+	err := rm.BaseSystem.Initialize(ctx)
+	if err != nil {
+		return err
+	}
+
+	eventTypes := []interfaces.EventType{
+		interfaces.PlayerShot,
+		interfaces.EnemyDamaged,
+		interfaces.BossDamaged,
+		interfaces.EnemyDestroyed,
+		interfaces.BossDefeated,
+	}
+
+	rm.events, err = rm.eventManager.SubscribeMany(eventTypes)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to run stats events: %w", err)
+	}
+
+	return nil
+}
+
+func (rm *RunStatsManager) Update(deltaTime float64) error {
+	select {
+	case <-rm.CTX.Done():
+		return rm.CTX.Err()
+	default:
+		rm.processEvents()
+		return nil
+	}
+}
+
+func (rm *RunStatsManager) processEvents() {
+	for {
+		select {
+		case evt, ok := <-rm.events:
+			if !ok {
+				return
+			}
+			rm.handleEvent(evt.Type)
+		default:
+			return
+		}
+	}
+}
+
+func (rm *RunStatsManager) handleEvent(eventType interfaces.EventType) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	switch eventType {
+	case interfaces.PlayerShot:
+		rm.stats.ShotsFired++
+	case interfaces.EnemyDamaged, interfaces.BossDamaged:
+		rm.stats.Hits++
+	case interfaces.EnemyDestroyed:
+		rm.stats.EnemiesKilled++
+	case interfaces.BossDefeated:
+		rm.stats.BossesDefeated++
+	}
+}
+
+func (rm *RunStatsManager) GetStats() RunStats {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	return rm.stats
+}
+
+// Reset remet les compteurs à zéro pour une nouvelle partie
+func (rm *RunStatsManager) Reset() {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.stats = RunStats{}
+}
+
+func (rm *RunStatsManager) Shutdown() {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if rm.events != nil {
+		rm.eventManager.Unsubscribe(interfaces.PlayerShot, rm.events)
+		rm.events = nil
+	}
+}
+
+var _ core.System = (*RunStatsManager)(nil)
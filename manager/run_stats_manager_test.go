@@ -0,0 +1,50 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajkula/shmup/entity"
+	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/mocks"
+	"github.com/ajkula/shmup/types"
+)
+
+func TestRunStatsManagerCountsEvents(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	rm := NewRunStatsManager(eventManager)
+	if err := rm.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize returned an error: %v", err)
+	}
+
+	player := entity.NewPlayer(types.Vector2D{}, eventManager)
+	enemy := entity.NewEnemy(types.Vector2D{}, eventManager)
+	boss := entity.NewBoss(types.Vector2D{}, eventManager)
+
+	for i := 0; i < 4; i++ {
+		eventManager.Publish(interfaces.PlayerShot, player)
+	}
+	eventManager.Publish(interfaces.EnemyDamaged, enemy)
+	eventManager.Publish(interfaces.EnemyDestroyed, enemy)
+	eventManager.Publish(interfaces.BossDamaged, boss)
+	eventManager.Publish(interfaces.BossDefeated, boss)
+	eventManager.Update(0)
+	rm.Update(0)
+
+	stats := rm.GetStats()
+	expected := RunStats{ShotsFired: 4, Hits: 2, EnemiesKilled: 1, BossesDefeated: 1}
+	if stats != expected {
+		t.Errorf("GetStats: got %+v, want %+v", stats, expected)
+	}
+	if stats.Accuracy() != 0.5 {
+		t.Errorf("Accuracy: got %v, want 0.5", stats.Accuracy())
+	}
+
+	rm.Reset()
+	if rm.GetStats() != (RunStats{}) {
+		t.Errorf("Stats after Reset: got %+v", rm.GetStats())
+	}
+	if (RunStats{}).Accuracy() != 0 {
+		t.Error("Accuracy without shots should be 0")
+	}
+}
@@ -3,27 +3,31 @@ package manager
 import (
 	"context"
 	"fmt"
+	"math"
 	"sync"
 
+	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/core"
 	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/topics"
 )
 
 type ScoreManager struct {
 	core.BaseSystem
-	score         int
-	highScore     int
-	eventManager  interfaces.EventManagerInterface
-	mu            sync.RWMutex
-	eventChannels map[interfaces.EventType]<-chan interfaces.Event
+	score        int
+	highScore    int
+	multiplier   float64
+	eventManager interfaces.EventManagerInterface
+	mu           sync.RWMutex
+	events       <-chan interfaces.Event
 }
 
 func NewScoreManager(eventManager interfaces.EventManagerInterface) *ScoreManager {
 	return &ScoreManager{
-		score:         0,
-		highScore:     0,
-		eventManager:  eventManager,
-		eventChannels: make(map[interfaces.EventType]<-chan interfaces.Event),
+		score:        0,
+		highScore:    0,
+		multiplier:   1,
+		eventManager: eventManager,
 	}
 }
 
@@ -33,7 +37,9 @@ func (sm *ScoreManager) Initialize(ctx context.Context) error {
 		return err
 	}
 
-	sm.eventChannels[interfaces.ScoreEvent], err = sm.eventManager.Subscribe(interfaces.ScoreEvent)
+	sm.SetMultiplier(config.FromContext(ctx).DifficultyPreset().ScoreMultiplier)
+
+	sm.events, err = sm.eventManager.SubscribeMany([]interfaces.EventType{interfaces.ScoreEvent}, interfaces.WithOverflow(interfaces.Grow))
 	if err != nil {
 		return fmt.Errorf("failed to subscribe to ScoreEvent: %w", err)
 	}
@@ -52,26 +58,24 @@ func (sm *ScoreManager) Update(deltaTime float64) error {
 }
 
 func (sm *ScoreManager) processEvents() {
-	for eventType, ch := range sm.eventChannels {
-		for {
-			select {
-			case evt, ok := <-ch:
-				if !ok {
-					return
-				}
-				sm.handleEvent(eventType, evt)
-			default:
-				// no more events
+	for {
+		select {
+		case evt, ok := <-sm.events:
+			if !ok {
 				return
 			}
+			sm.handleEvent(evt)
+		default:
+			// no more events
+			return
 		}
 	}
 }
 
-func (sm *ScoreManager) handleEvent(eventType interfaces.EventType, evt interfaces.Event) {
-	switch eventType {
+func (sm *ScoreManager) handleEvent(evt interfaces.Event) {
+	switch evt.Type {
 	case interfaces.ScoreEvent:
-		if scoreChange, ok := evt.Data.(int); ok {
+		if scoreChange, ok := topics.Score.Payload(evt); ok {
 			sm.AddScore(scoreChange)
 		}
 	}
@@ -80,12 +84,19 @@ func (sm *ScoreManager) handleEvent(eventType interfaces.EventType, evt interfac
 func (sm *ScoreManager) AddScore(points int) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
-	sm.score += points
+	sm.score += int(math.Round(float64(points) * sm.multiplier))
 	if sm.score > sm.highScore {
 		sm.highScore = sm.score
 	}
 }
 
+// SetMultiplier règle le multiplicateur de score du preset de difficulté
+func (sm *ScoreManager) SetMultiplier(multiplier float64) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.multiplier = multiplier
+}
+
 func (sm *ScoreManager) GetScore() int {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
@@ -102,20 +113,18 @@ func (sm *ScoreManager) ResetScore() {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 	sm.score = 0
-	sm.eventManager.Publish(interfaces.ScoreEvent, sm.score)
-	fmt.Println("Score reset")
+	topics.Score.Publish(sm.eventManager, sm.score)
 }
 
 func (sm *ScoreManager) Shutdown() {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
-	for eventType, ch := range sm.eventChannels {
-		sm.eventManager.Unsubscribe(eventType, ch)
+	if sm.events != nil {
+		sm.eventManager.Unsubscribe(interfaces.ScoreEvent, sm.events)
+		sm.events = nil
 	}
-	sm.eventChannels = nil
 	sm.score = 0
 	sm.highScore = 0
-	fmt.Println("ScoreManager shut down")
 }
 
 var _ core.System = (*ScoreManager)(nil)
@@ -6,8 +6,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/interfaces"
 	"github.com/ajkula/shmup/mocks"
+	"github.com/ajkula/shmup/topics"
 )
 
 func TestNewScoreManager(t *testing.T) {
@@ -162,3 +164,18 @@ func TestScoreManagerShutdown(t *testing.T) {
 		t.Errorf("Expected high score to be 0 after shutdown, got %d", sm.GetHighScore())
 	}
 }
+
+func TestScoreManagerDifficultyMultiplier(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	sm := NewScoreManager(eventManager)
+	cfg := config.Default()
+	cfg.Difficulty = "Lunatic"
+	sm.Initialize(config.WithContext(context.Background(), cfg))
+
+	topics.Score.Publish(eventManager, 100)
+	sm.Update(0.16)
+
+	if sm.GetScore() != 250 {
+		t.Errorf("Lunatic score for 100 points: got %d, want 250", sm.GetScore())
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/ajkula/shmup/interfaces"
 	"github.com/ajkula/shmup/testinterfaces"
 	"github.com/ajkula/shmup/types"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type MockBullet struct {
@@ -43,7 +42,7 @@ func (m *MockBullet) Update(deltaTime float64) error {
 	return nil
 }
 
-func (m *MockBullet) Draw(screen *ebiten.Image) {
+func (m *MockBullet) Draw(screen types.Screen) {
 	// Mock implem
 }
 
@@ -4,7 +4,6 @@ import (
 	"image/color"
 
 	"github.com/ajkula/shmup/types"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type MockEnemy struct {
@@ -18,7 +17,7 @@ func (m *MockEnemy) Update(deltaTime float64) error {
 	return m.UpdateError
 }
 
-func (m *MockEnemy) Draw(screen *ebiten.Image)              {}
+func (m *MockEnemy) Draw(screen types.Screen)               {}
 func (m *MockEnemy) GetPosition() types.Vector2D            { return types.Vector2D{} }
 func (m *MockEnemy) SetPosition(pos types.Vector2D)         {}
 func (m *MockEnemy) GetSize() (width, height float64)       { return 0, 0 }
@@ -6,13 +6,23 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ajkula/shmup/event"
 	"github.com/ajkula/shmup/interfaces"
 )
 
+type mockFilteredSubscriber struct {
+	match func(interfaces.Event) bool
+	ch    chan interfaces.Event
+}
+
 type MockEventManager struct {
 	mu              sync.RWMutex
 	subscribers     map[interfaces.EventType][]chan interfaces.Event
+	filtered        []mockFilteredSubscriber
 	publishedEvents []interfaces.Event
+	typeStats       map[interfaces.EventType]*interfaces.EventStats
+	subscriberStats map[<-chan interfaces.Event]*interfaces.SubscriberStats
+	timers          *event.TimerQueue
 	ctx             context.Context
 	cancel          context.CancelFunc
 }
@@ -22,6 +32,9 @@ func NewMockEventManager() *MockEventManager {
 	return &MockEventManager{
 		subscribers:     make(map[interfaces.EventType][]chan interfaces.Event),
 		publishedEvents: []interfaces.Event{},
+		typeStats:       make(map[interfaces.EventType]*interfaces.EventStats),
+		subscriberStats: make(map[<-chan interfaces.Event]*interfaces.SubscriberStats),
+		timers:          event.NewTimerQueue(),
 		ctx:             ctx,
 		cancel:          cancel,
 	}
@@ -38,6 +51,9 @@ func (m *MockEventManager) Update(deltaTime float64) error {
 	case <-m.ctx.Done():
 		return m.ctx.Err()
 	default:
+		for _, evt := range m.timers.Advance(deltaTime) {
+			m.Publish(evt.Type, evt.Data)
+		}
 		return nil
 	}
 }
@@ -56,35 +72,111 @@ func (m *MockEventManager) Shutdown() {
 			close(ch)
 		}
 	}
+	for _, sub := range m.filtered {
+		close(sub.ch)
+	}
 	m.subscribers = make(map[interfaces.EventType][]chan interfaces.Event)
+	m.filtered = nil
 	m.publishedEvents = []interfaces.Event{}
+	m.subscriberStats = make(map[<-chan interfaces.Event]*interfaces.SubscriberStats)
 }
 
 func (m *MockEventManager) Publish(eventType interfaces.EventType, data interface{}) error {
+	if err := event.ValidatePayload(eventType, data); err != nil {
+		return err
+	}
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	event := interfaces.Event{Type: eventType, Data: data}
-	m.publishedEvents = append(m.publishedEvents, event)
+	evt := interfaces.Event{Type: eventType, Data: data}
+	m.publishedEvents = append(m.publishedEvents, evt)
+	m.statsForType(eventType).Published++
+	matched := 0
+	for _, sub := range m.filtered {
+		if sub.match(evt) {
+			matched++
+			select {
+			case sub.ch <- evt:
+				m.record(sub.ch, eventType, true)
+			default:
+				m.record(sub.ch, eventType, false)
+			}
+		}
+	}
 	subscribers, ok := m.subscribers[eventType]
-	if !ok {
-		return fmt.Errorf("failed to Publish %d", eventType)
+	if !ok && matched == 0 {
+		return fmt.Errorf("failed to Publish %v", eventType)
 	}
 	for _, ch := range subscribers {
 		select {
-		case ch <- event:
-			// success
+		case ch <- evt:
+			m.record(ch, eventType, true)
 		default:
 			// channel is full, skip this subscriber
+			m.record(ch, eventType, false)
 		}
 	}
 	return nil
 }
 
-func (m *MockEventManager) Subscribe(eventType interfaces.EventType) (<-chan interfaces.Event, error) {
+// statsForType suppose m.mu déjà verrouillé
+func (m *MockEventManager) statsForType(eventType interfaces.EventType) *interfaces.EventStats {
+	stats, ok := m.typeStats[eventType]
+	if !ok {
+		stats = &interfaces.EventStats{}
+		m.typeStats[eventType] = stats
+	}
+	return stats
+}
+
+// record compte une livraison ou une perte; suppose m.mu déjà verrouillé
+func (m *MockEventManager) record(ch <-chan interfaces.Event, eventType interfaces.EventType, delivered bool) {
+	typeStats := m.statsForType(eventType)
+	subStats := m.subscriberStats[ch]
+	if delivered {
+		typeStats.Delivered++
+		subStats.Delivered++
+	} else {
+		typeStats.Dropped++
+		subStats.Dropped++
+	}
+}
+
+func (m *MockEventManager) Subscribe(eventType interfaces.EventType, opts ...interfaces.SubscribeOption) (<-chan interfaces.Event, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	ch := make(chan interfaces.Event, 100)
+	ch := make(chan interfaces.Event, interfaces.NewSubscribeConfig(opts...).BufferSize)
 	m.subscribers[eventType] = append(m.subscribers[eventType], ch)
+	m.subscriberStats[ch] = &interfaces.SubscriberStats{
+		EventType: eventType,
+		Overflow:  interfaces.NewSubscribeConfig(opts...).Overflow,
+	}
+	return ch, nil
+}
+
+func (m *MockEventManager) SubscribeAll(opts ...interfaces.SubscribeOption) (<-chan interfaces.Event, error) {
+	return m.SubscribeFunc(func(interfaces.Event) bool { return true }, opts...)
+}
+
+func (m *MockEventManager) SubscribeMany(eventTypes []interfaces.EventType, opts ...interfaces.SubscribeOption) (<-chan interfaces.Event, error) {
+	return m.SubscribeFunc(func(evt interfaces.Event) bool {
+		for _, eventType := range eventTypes {
+			if evt.Type == eventType {
+				return true
+			}
+		}
+		return false
+	}, opts...)
+}
+
+func (m *MockEventManager) SubscribeFunc(predicate func(interfaces.Event) bool, opts ...interfaces.SubscribeOption) (<-chan interfaces.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ch := make(chan interfaces.Event, interfaces.NewSubscribeConfig(opts...).BufferSize)
+	m.filtered = append(m.filtered, mockFilteredSubscriber{match: predicate, ch: ch})
+	m.subscriberStats[ch] = &interfaces.SubscriberStats{
+		Filtered: true,
+		Overflow: interfaces.NewSubscribeConfig(opts...).Overflow,
+	}
 	return ch, nil
 }
 
@@ -94,15 +186,66 @@ func (m *MockEventManager) Unsubscribe(eventType interfaces.EventType, ch <-chan
 	if subscribers, ok := m.subscribers[eventType]; ok {
 		for i, subscriber := range subscribers {
 			if subscriber == ch {
+				delete(m.subscriberStats, ch)
 				close(subscriber)
 				m.subscribers[eventType] = append(subscribers[:i], subscribers[i+1:]...)
-				break
+				return nil
 			}
 		}
 	}
+	for i, sub := range m.filtered {
+		if sub.ch == ch {
+			delete(m.subscriberStats, ch)
+			close(sub.ch)
+			m.filtered = append(m.filtered[:i], m.filtered[i+1:]...)
+			break
+		}
+	}
 	return nil
 }
 
+func (m *MockEventManager) PublishAfter(delay interfaces.GameDuration, eventType interfaces.EventType, data interface{}) (interfaces.TimerHandle, error) {
+	return m.timers.Add(delay, false, eventType, data)
+}
+
+func (m *MockEventManager) PublishEvery(interval interfaces.GameDuration, eventType interfaces.EventType, data interface{}) (interfaces.TimerHandle, error) {
+	return m.timers.Add(interval, true, eventType, data)
+}
+
+func (m *MockEventManager) CancelTimer(handle interfaces.TimerHandle) bool {
+	return m.timers.Cancel(handle)
+}
+
+func (m *MockEventManager) PauseTimers() {
+	m.timers.SetPaused(true)
+}
+
+func (m *MockEventManager) ResumeTimers() {
+	m.timers.SetPaused(false)
+}
+
+func (m *MockEventManager) Stats() map[interfaces.EventType]interfaces.EventStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	stats := make(map[interfaces.EventType]interfaces.EventStats, len(m.typeStats))
+	for eventType, counters := range m.typeStats {
+		stats[eventType] = *counters
+	}
+	return stats
+}
+
+func (m *MockEventManager) StatsFor(ch <-chan interfaces.Event) (interfaces.SubscriberStats, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	counters, ok := m.subscriberStats[ch]
+	if !ok {
+		return interfaces.SubscriberStats{}, false
+	}
+	stats := *counters
+	stats.Queued = len(ch)
+	return stats, true
+}
+
 func (m *MockEventManager) GetPublishedEvents() []interfaces.Event {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
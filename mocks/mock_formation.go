@@ -4,7 +4,6 @@ import (
 	"image/color"
 
 	"github.com/ajkula/shmup/types"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type MockFormation struct {
@@ -17,7 +16,7 @@ func (m *MockFormation) Update(deltaTime float64) error {
 	return m.updateError
 }
 
-func (m *MockFormation) Draw(screen *ebiten.Image)                {}
+func (m *MockFormation) Draw(screen types.Screen)                 {}
 func (m *MockFormation) GetPosition() types.Vector2D              { return types.Vector2D{} }
 func (m *MockFormation) SetPosition(pos types.Vector2D)           {}
 func (m *MockFormation) GetSize() (width, height float64)         { return 0, 0 }
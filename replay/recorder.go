@@ -0,0 +1,114 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/interfaces"
+)
+
+// Record est une ligne du journal JSONL
+type Record struct {
+	Tick uint64               `json:"tick"`
+	Time time.Time            `json:"time"`
+	Type interfaces.EventType `json:"type"`
+	Name string               `json:"name,omitempty"`
+	Data json.RawMessage      `json:"data"`
+}
+
+// Recorder écrit chaque événement distribué, estampillé du tick où il est reçu
+type Recorder struct {
+	core.BaseSystem
+	eventManager interfaces.EventManagerInterface
+	encoder      *json.Encoder
+	events       <-chan interfaces.Event
+	tick         uint64
+	mu           sync.Mutex
+}
+
+func NewRecorder(eventManager interfaces.EventManagerInterface, w io.Writer) *Recorder {
+	return &Recorder{
+		eventManager: eventManager,
+		encoder:      json.NewEncoder(w),
+	}
+}
+
+func (r *Recorder) Initialize(ctx context.Context) error {
+	if err := r.BaseSystem.Initialize(ctx); err != nil {
+		return err
+	}
+
+	// un enregistrement ne doit rien perdre ni réordonner
+	events, err := r.eventManager.SubscribeAll(interfaces.WithOverflow(interfaces.Grow))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe recorder: %w", err)
+	}
+	r.events = events
+	return nil
+}
+
+func (r *Recorder) Update(deltaTime float64) error {
+	select {
+	case <-r.CTX.Done():
+		return r.CTX.Err()
+	default:
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.tick++
+		return r.drain()
+	}
+}
+
+func (r *Recorder) drain() error {
+	for {
+		select {
+		case evt, ok := <-r.events:
+			if !ok {
+				return nil
+			}
+			if err := r.write(evt); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+func (r *Recorder) write(evt interfaces.Event) error {
+	data, err := SerializePayload(evt.Data)
+	if err != nil {
+		return err
+	}
+	record := Record{
+		Tick: r.tick,
+		Time: time.Now(),
+		Type: evt.Type,
+		Name: evt.Type.String(),
+		Data: data,
+	}
+	if err := r.encoder.Encode(record); err != nil {
+		return fmt.Errorf("failed to write record: %w", err)
+	}
+	return nil
+}
+
+func (r *Recorder) Run(ctx context.Context) error {
+	return r.BaseSystem.Run(ctx)
+}
+
+func (r *Recorder) Shutdown() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.events != nil {
+		r.eventManager.Unsubscribe(interfaces.InputEvent, r.events)
+		r.events = nil
+	}
+}
+
+var _ core.System = (*Recorder)(nil)
@@ -0,0 +1,65 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ajkula/shmup/entity"
+	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/mocks"
+	"github.com/ajkula/shmup/types"
+)
+
+func TestSerializePayloadEntity(t *testing.T) {
+	player := entity.NewPlayer(types.Vector2D{X: 10, Y: 20}, mocks.NewMockEventManager())
+
+	raw, err := SerializePayload(player)
+	if err != nil {
+		t.Fatalf("SerializePayload returned an error: %v", err)
+	}
+
+	var snapshot EntitySnapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		t.Fatalf("Invalid snapshot JSON: %v", err)
+	}
+	if snapshot.Kind != "Player" || snapshot.X != 10 || snapshot.Y != 20 || snapshot.Health != 100 {
+		t.Errorf("Unexpected snapshot: %+v", snapshot)
+	}
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	var buf bytes.Buffer
+	recorder := NewRecorder(eventManager, &buf)
+	if err := recorder.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize returned an error: %v", err)
+	}
+
+	recorder.Update(0.16)
+	eventManager.Publish(interfaces.InputEvent, "shoot")
+	eventManager.Publish(interfaces.ScoreEvent, 100)
+	recorder.Update(0.16)
+
+	records, err := ReadRecords(&buf)
+	if err != nil {
+		t.Fatalf("ReadRecords returned an error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	for _, record := range records {
+		if record.Tick != 2 {
+			t.Errorf("Expected records at tick 2, got %d", record.Tick)
+		}
+	}
+
+	script, err := InputScript(records)
+	if err != nil {
+		t.Fatalf("InputScript returned an error: %v", err)
+	}
+	if len(script[2]) != 1 || script[2][0] != "shoot" {
+		t.Errorf("Unexpected input script: %v", script)
+	}
+}
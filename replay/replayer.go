@@ -0,0 +1,74 @@
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ajkula/shmup/input"
+	"github.com/ajkula/shmup/interfaces"
+)
+
+func ReadRecords(r io.Reader) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("invalid record on line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+	return records, nil
+}
+
+// InputScript extrait les InputEvent enregistrés, indexés par tick
+func InputScript(records []Record) (map[uint64][]string, error) {
+	script := make(map[uint64][]string)
+	for _, record := range records {
+		if record.Type != interfaces.InputEvent {
+			continue
+		}
+		var command string
+		if err := json.Unmarshal(record.Data, &command); err != nil {
+			return nil, fmt.Errorf("invalid input payload at tick %d: %w", record.Tick, err)
+		}
+		script[record.Tick] = append(script[record.Tick], command)
+	}
+	return script, nil
+}
+
+// LoadInputScript lit un enregistrement JSONL et en extrait les entrées
+func LoadInputScript(path string) (map[uint64][]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records, err := ReadRecords(f)
+	if err != nil {
+		return nil, err
+	}
+	return InputScript(records)
+}
+
+// LoadInputSource lit un enregistrement et le rejoue via InputSystem, qui
+// publie avant l'EventManager: chaque entrée est distribuée au tick où elle
+// a été enregistrée, tick 1 compris.
+func LoadInputSource(path string) (*input.Scripted, error) {
+	script, err := LoadInputScript(path)
+	if err != nil {
+		return nil, err
+	}
+	return input.NewScripted(script), nil
+}
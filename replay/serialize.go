@@ -0,0 +1,70 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ajkula/shmup/types"
+)
+
+type EntitySnapshot struct {
+	Kind   string  `json:"kind"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Health int     `json:"health"`
+}
+
+type FormationSnapshot struct {
+	EntitySnapshot
+	FormationType types.FormationType `json:"formationType"`
+	Members       int                 `json:"members"`
+}
+
+func kindOf(v interface{}) string {
+	kind := fmt.Sprintf("%T", v)
+	kind = strings.TrimPrefix(kind, "*")
+	if i := strings.LastIndex(kind, "."); i >= 0 {
+		kind = kind[i+1:]
+	}
+	return kind
+}
+
+func snapshotEntity(e types.Entity) EntitySnapshot {
+	pos := e.GetPosition()
+	width, height := e.GetSize()
+	return EntitySnapshot{
+		Kind:   kindOf(e),
+		X:      pos.X,
+		Y:      pos.Y,
+		Width:  width,
+		Height: height,
+		Health: e.GetHealth(),
+	}
+}
+
+// SerializePayload transforme la charge utile d'un événement en JSON.
+// Les entités sont réduites à un instantané, le reste est encodé tel quel.
+func SerializePayload(data interface{}) (json.RawMessage, error) {
+	var value interface{}
+	switch d := data.(type) {
+	case types.Formation:
+		value = FormationSnapshot{
+			EntitySnapshot: snapshotEntity(d),
+			FormationType:  d.GetFormationType(),
+			Members:        len(d.GetEntities()),
+		}
+	case types.Entity:
+		value = snapshotEntity(d)
+	default:
+		value = d
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize %T payload: %w", data, err)
+	}
+	return raw, nil
+}
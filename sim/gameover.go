@@ -0,0 +1,249 @@
+package sim
+
+import (
+	"fmt"
+
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/entity"
+	"github.com/ajkula/shmup/input"
+	"github.com/ajkula/shmup/manager"
+	"github.com/ajkula/shmup/state"
+	"github.com/ajkula/shmup/types"
+)
+
+// RunSummary est le bilan affiché à la fin d'une partie
+type RunSummary struct {
+	Score          int     `json:"score"`
+	Level          int     `json:"level"`
+	Accuracy       float64 `json:"accuracy"`
+	EnemiesKilled  int     `json:"enemiesKilled"`
+	BossesDefeated int     `json:"bossesDefeated"`
+}
+
+func newRunSummary(scoreManager *manager.ScoreManager, levelManager *manager.LevelManager, runStats *manager.RunStatsManager) RunSummary {
+	stats := runStats.GetStats()
+	return RunSummary{
+		Score:          scoreManager.GetScore(),
+		Level:          levelManager.GetLevel(),
+		Accuracy:       stats.Accuracy(),
+		EnemiesKilled:  stats.EnemiesKilled,
+		BossesDefeated: stats.BossesDefeated,
+	}
+}
+
+type gameOverPhase int
+
+const (
+	phaseContinue gameOverPhase = iota
+	phaseSummary
+)
+
+// gameOverScene propose un continue tant qu'il reste des crédits et du temps,
+// puis affiche le bilan et passe à la saisie du high score si la partie qualifie
+type gameOverScene struct {
+	state.BaseScene
+	states       *state.StateManager
+	player       *entity.Player
+	scoreManager *manager.ScoreManager
+	levelManager *manager.LevelManager
+	runStats     *manager.RunStatsManager
+	highScores   *manager.HighScoreTable
+	cfg          config.GameConfig
+	credits      int
+	countdown    float64
+	phase        gameOverPhase
+	summary      RunSummary
+}
+
+func newGameOverScene(states *state.StateManager, cfg config.GameConfig, player *entity.Player, scoreManager *manager.ScoreManager, levelManager *manager.LevelManager, runStats *manager.RunStatsManager, highScores *manager.HighScoreTable) *gameOverScene {
+	return &gameOverScene{
+		states:       states,
+		player:       player,
+		scoreManager: scoreManager,
+		levelManager: levelManager,
+		runStats:     runStats,
+		highScores:   highScores,
+		cfg:          cfg,
+		credits:      cfg.Credits,
+	}
+}
+
+func (s *gameOverScene) State() state.GameState { return state.StateGameOver }
+
+// resetCredits redonne les crédits de départ pour une nouvelle partie
+func (s *gameOverScene) resetCredits() {
+	s.credits = s.cfg.Credits
+}
+
+func (s *gameOverScene) Enter(from state.GameState) error {
+	s.summary = newRunSummary(s.scoreManager, s.levelManager, s.runStats)
+	s.countdown = s.cfg.ContinueSeconds
+	s.phase = phaseContinue
+	if s.credits <= 0 {
+		s.phase = phaseSummary
+	}
+	return nil
+}
+
+func (s *gameOverScene) HandleInput(command string) {
+	action, held := input.Parse(command)
+	if held {
+		return
+	}
+	switch s.phase {
+	case phaseContinue:
+		switch action {
+		case input.Confirm:
+			s.continueRun()
+		case input.Back:
+			s.phase = phaseSummary
+		}
+	case phaseSummary:
+		if action != input.Confirm {
+			return
+		}
+		if s.highScores.Qualifies(s.summary.Score) {
+			s.states.Replace(state.StateHighScoreEntry)
+		} else {
+			s.states.Replace(state.StateMainMenu)
+		}
+	}
+}
+
+// continueRun dépense un crédit: le score repart de zéro, le niveau est conservé
+func (s *gameOverScene) continueRun() {
+	s.credits--
+	s.scoreManager.ResetScore()
+	s.player.Revive()
+	s.states.Replace(state.StatePlaying)
+}
+
+func (s *gameOverScene) Update(deltaTime float64) error {
+	if s.phase != phaseContinue {
+		return nil
+	}
+	s.countdown -= deltaTime
+	if s.countdown <= 0 {
+		s.countdown = 0
+		s.phase = phaseSummary
+	}
+	return nil
+}
+
+func (s *gameOverScene) Draw(screen types.Screen) {
+	screen.DebugPrintAt("GAME OVER", 40, 40)
+	if s.phase == phaseContinue {
+		screen.DebugPrintAt(fmt.Sprintf("Continue? %d", int(s.countdown+0.999)), 40, 80)
+		screen.DebugPrintAt(fmt.Sprintf("Credits: %d", s.credits), 40, 100)
+		screen.DebugPrintAt("Enter: continue  Esc: give up", 40, 140)
+		return
+	}
+	lines := []string{
+		fmt.Sprintf("Score: %d", s.summary.Score),
+		fmt.Sprintf("Level reached: %d", s.summary.Level),
+		fmt.Sprintf("Accuracy: %.1f%%", s.summary.Accuracy*100),
+		fmt.Sprintf("Enemies killed: %d", s.summary.EnemiesKilled),
+		fmt.Sprintf("Bosses defeated: %d", s.summary.BossesDefeated),
+		"",
+		"Press Enter",
+	}
+	for i, line := range lines {
+		screen.DebugPrintAt(line, 40, 80+20*i)
+	}
+}
+
+const (
+	initialsLength = 3
+	initialsFirst  = 'A'
+	initialsLast   = 'Z'
+)
+
+// highScoreEntryScene fait saisir trois initiales: up/down change la lettre,
+// right ou confirm passe à la suivante, left revient à la précédente
+type highScoreEntryScene struct {
+	state.BaseScene
+	states       *state.StateManager
+	scoreManager *manager.ScoreManager
+	levelManager *manager.LevelManager
+	highScores   *manager.HighScoreTable
+	initials     [initialsLength]byte
+	cursor       int
+}
+
+func newHighScoreEntryScene(states *state.StateManager, scoreManager *manager.ScoreManager, levelManager *manager.LevelManager, highScores *manager.HighScoreTable) *highScoreEntryScene {
+	return &highScoreEntryScene{
+		states:       states,
+		scoreManager: scoreManager,
+		levelManager: levelManager,
+		highScores:   highScores,
+	}
+}
+
+func (s *highScoreEntryScene) State() state.GameState { return state.StateHighScoreEntry }
+
+func (s *highScoreEntryScene) Enter(from state.GameState) error {
+	for i := range s.initials {
+		s.initials[i] = initialsFirst
+	}
+	s.cursor = 0
+	return nil
+}
+
+func (s *highScoreEntryScene) HandleInput(command string) {
+	action, held := input.Parse(command)
+	if held {
+		return
+	}
+	letter := &s.initials[s.cursor]
+	switch action {
+	case input.MoveUp:
+		if *letter == initialsLast {
+			*letter = initialsFirst
+		} else {
+			*letter++
+		}
+	case input.MoveDown:
+		if *letter == initialsFirst {
+			*letter = initialsLast
+		} else {
+			*letter--
+		}
+	case input.MoveLeft:
+		if s.cursor > 0 {
+			s.cursor--
+		}
+	case input.MoveRight, input.Confirm:
+		if s.cursor < initialsLength-1 {
+			s.cursor++
+			return
+		}
+		if action == input.Confirm {
+			s.highScores.Insert(manager.HighScoreEntry{
+				Name:  string(s.initials[:]),
+				Score: s.scoreManager.GetScore(),
+				Level: s.levelManager.GetLevel(),
+			})
+			s.states.Replace(state.StateMainMenu)
+		}
+	}
+}
+
+func (s *highScoreEntryScene) Draw(screen types.Screen) {
+	screen.DebugPrintAt("NEW HIGH SCORE", 40, 40)
+	screen.DebugPrintAt(fmt.Sprintf("Score: %d", s.scoreManager.GetScore()), 40, 60)
+	for i, letter := range s.initials {
+		text := string(letter)
+		if i == s.cursor {
+			text = "[" + text + "]"
+		}
+		screen.DebugPrintAt(text, 40+30*i, 100)
+	}
+	for i, entry := range s.highScores.Entries() {
+		screen.DebugPrintAt(fmt.Sprintf("%2d. %s %8d  L%d", i+1, entry.Name, entry.Score, entry.Level), 40, 140+20*i)
+	}
+}
+
+var (
+	_ state.Scene = (*gameOverScene)(nil)
+	_ state.Scene = (*highScoreEntryScene)(nil)
+)
@@ -0,0 +1,87 @@
+package sim
+
+import (
+	"context"
+
+	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/event"
+	"github.com/ajkula/shmup/manager"
+	"github.com/ajkula/shmup/state"
+	"github.com/ajkula/shmup/system"
+)
+
+type Summary struct {
+	Ticks        uint64     `json:"ticks"`
+	GameTicks    uint64     `json:"gameTicks"`
+	State        string     `json:"state"`
+	Score        int        `json:"score"`
+	HighScore    int        `json:"highScore"`
+	Level        int        `json:"level"`
+	PlayerHealth int        `json:"playerHealth"`
+	EnemiesAlive int        `json:"enemiesAlive"`
+	Run          RunSummary `json:"run"`
+	BulletsAlive int        `json:"bulletsAlive"`
+}
+
+// RunHeadless construit les mêmes systèmes que NewSimulation et avance de ticks pas fixes,
+// sans fenêtre ni clavier: les entrées viennent de WithInputSource (script, replay,
+// bot). Les événements sont toujours distribués en début de tick pour que deux
+// exécutions identiques donnent le même résultat.
+// La partie démarre directement en jeu, sauf si WithInitialState est fourni.
+func RunHeadless(ctx context.Context, ticks int, opts ...Option) (Summary, error) {
+	opts = append([]Option{WithInitialState(state.StatePlaying)}, opts...)
+	s, err := NewSimulation(ctx, append(opts, WithDispatchMode(event.DispatchPerTick))...)
+	if err != nil {
+		return Summary{}, err
+	}
+	defer s.Shutdown()
+
+	for i := 0; i < ticks; i++ {
+		select {
+		case <-s.ctx.Done():
+			return s.Summary(), s.ctx.Err()
+		default:
+		}
+
+		if err := s.Tick(); err != nil {
+			return s.Summary(), err
+		}
+	}
+
+	return s.Summary(), nil
+}
+
+func (s *Simulation) Summary() Summary {
+	summary := Summary{
+		Ticks:        s.scheduler.CurrentTick(),
+		State:        s.states.GetState().String(),
+		PlayerHealth: s.player.GetHealth(),
+	}
+	if sm, ok := core.Lookup[*manager.ScoreManager](s.registry); ok {
+		summary.Score = sm.GetScore()
+		summary.HighScore = sm.GetHighScore()
+	}
+	if gc, ok := core.Lookup[*system.GameClock](s.registry); ok {
+		summary.GameTicks = gc.Ticks()
+	}
+	if lm, ok := core.Lookup[*manager.LevelManager](s.registry); ok {
+		summary.Level = lm.GetLevel()
+	}
+	if em, ok := core.Lookup[*manager.EnemyManager](s.registry); ok {
+		summary.EnemiesAlive = em.GetEnemyCount()
+	}
+	if rm, ok := core.Lookup[*manager.RunStatsManager](s.registry); ok {
+		stats := rm.GetStats()
+		summary.Run = RunSummary{
+			Score:          summary.Score,
+			Level:          summary.Level,
+			Accuracy:       stats.Accuracy(),
+			EnemiesKilled:  stats.EnemiesKilled,
+			BossesDefeated: stats.BossesDefeated,
+		}
+	}
+	if bm, ok := core.Lookup[*manager.BulletManager](s.registry); ok {
+		summary.BulletsAlive = bm.GetBulletCount()
+	}
+	return summary
+}
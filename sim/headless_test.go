@@ -0,0 +1,67 @@
+package sim
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/input"
+	"github.com/ajkula/shmup/replay"
+)
+
+// recordRun joue ticks pas avec source et enregistre les événements dans path
+func recordRun(t *testing.T, path string, ticks int, source input.InputSource) Summary {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	summary, err := RunHeadless(context.Background(), ticks,
+		WithConfig(config.Default()), WithInputSource(source), WithRecorder(f))
+	if err != nil {
+		t.Fatalf("RunHeadless returned an error: %v", err)
+	}
+	return summary
+}
+
+func TestReplayedInputsKeepTheirTicks(t *testing.T) {
+	script := map[uint64][]string{
+		1:  {input.Fire.Pressed(), input.Fire.Held()},
+		2:  {input.MoveLeft.Held(), input.Fire.Held()},
+		40: {input.Focus.Held(), input.MoveUp.Held()},
+	}
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.jsonl")
+	original := recordRun(t, first, 60, input.NewScripted(script))
+
+	source, err := replay.LoadInputSource(first)
+	if err != nil {
+		t.Fatalf("LoadInputSource returned an error: %v", err)
+	}
+	expected := input.NewScripted(script)
+	for tick := uint64(1); tick <= 60; tick++ {
+		want, got := expected.Poll(tick), source.Poll(tick)
+		for _, action := range input.Actions() {
+			if want.IsPressed(action) != got.IsPressed(action) || want.IsHeld(action) != got.IsHeld(action) {
+				t.Errorf("tick %d: replayed %+v, recorded %+v", tick, got, want)
+				break
+			}
+		}
+	}
+
+	second := filepath.Join(dir, "second.jsonl")
+	source, _ = replay.LoadInputSource(first)
+	replayed := recordRun(t, second, 60, source)
+	firstScript, _ := replay.LoadInputScript(first)
+	secondScript, _ := replay.LoadInputScript(second)
+	if !reflect.DeepEqual(firstScript, secondScript) {
+		t.Errorf("replaying should record the same inputs:\n%v\n%v", firstScript, secondScript)
+	}
+	if replayed != original {
+		t.Errorf("replayed run %+v differs from the original %+v", replayed, original)
+	}
+}
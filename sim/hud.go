@@ -0,0 +1,66 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/manager"
+	"github.com/ajkula/shmup/types"
+)
+
+// debugHUD affiche le rang dynamique dans les builds -tags debug
+type debugHUD struct {
+	core.BaseSystem
+	eventManager interfaces.EventManagerInterface
+	rankChan     <-chan interfaces.Event
+	rank         float64
+}
+
+func newDebugHUD(eventManager interfaces.EventManagerInterface) *debugHUD {
+	return &debugHUD{eventManager: eventManager}
+}
+
+func (h *debugHUD) Initialize(ctx context.Context) error {
+	err := h.BaseSystem.Initialize(ctx)
+	if err != nil {
+		return err
+	}
+	h.rankChan, err = h.eventManager.Subscribe(manager.RankChanged)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to RankChanged: %w", err)
+	}
+	return nil
+}
+
+func (h *debugHUD) Update(deltaTime float64) error {
+	select {
+	case <-h.CTX.Done():
+		return h.CTX.Err()
+	default:
+		for {
+			select {
+			case evt, ok := <-h.rankChan:
+				if !ok {
+					return nil
+				}
+				if change, ok := manager.RankTopic.Payload(evt); ok {
+					h.rank = change.Rank
+				}
+			default:
+				return nil
+			}
+		}
+	}
+}
+
+func (h *debugHUD) Draw(screen types.Screen) {
+	screen.DebugPrintAt(fmt.Sprintf("rank %.2f", h.rank), 4, 4)
+}
+
+func (h *debugHUD) Shutdown() {
+	h.eventManager.Unsubscribe(manager.RankChanged, h.rankChan)
+}
+
+var _ core.System = (*debugHUD)(nil)
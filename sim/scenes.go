@@ -0,0 +1,402 @@
+package sim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/entity"
+	"github.com/ajkula/shmup/input"
+	"github.com/ajkula/shmup/state"
+	"github.com/ajkula/shmup/system"
+	"github.com/ajkula/shmup/types"
+)
+
+// systèmes exécutés quelle que soit la scène au sommet
+var alwaysTicked = map[string]bool{
+	EventManagerName:   true,
+	RecorderName:       true,
+	StateManagerName:   true,
+	InputSystemName:    true,
+	RenderSystemName:   true,
+	ConfigReloaderName: true,
+	DebugHUDName:       true,
+}
+
+// systèmes figés hors de la scène de jeu
+var gameplaySystems = []string{
+	GameClockName,
+	UpdateSystemName,
+	EnemyManagerName,
+	BulletManagerName,
+	CollisionSystemName,
+	ScoreManagerName,
+	LevelManagerName,
+	RunStatsManagerName,
+	RankManagerName,
+}
+
+// menu est une liste verticale navigable avec MoveUp/MoveDown et validée par
+// Confirm ou Fire. Une touche liée aux deux (A sur la manette) publie les deux
+// actions au même tick: seule la première valide, jusqu'au endTick suivant.
+type menu struct {
+	title    string
+	items    []string
+	cursor   int
+	selected bool
+}
+
+func (m *menu) handleInput(action input.Action) (string, bool) {
+	switch action {
+	case input.MoveUp:
+		m.cursor = (m.cursor + len(m.items) - 1) % len(m.items)
+	case input.MoveDown:
+		m.cursor = (m.cursor + 1) % len(m.items)
+	case input.Confirm, input.Fire:
+		if m.selected {
+			return "", false
+		}
+		m.selected = true
+		return m.items[m.cursor], true
+	}
+	return "", false
+}
+
+// endTick autorise une nouvelle validation, à appeler depuis l'Update de la scène
+func (m *menu) endTick() {
+	m.selected = false
+}
+
+func (m *menu) draw(screen types.Screen, x, y int) {
+	screen.DebugPrintAt(m.title, x, y)
+	for i, item := range m.items {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		screen.DebugPrintAt(prefix+item, x, y+20*(i+1))
+	}
+}
+
+// position de l'entrée de difficulté dans le menu principal
+const difficultyItem = 1
+
+type mainMenuScene struct {
+	state.BaseScene
+	states     *state.StateManager
+	difficulty *config.DifficultyPreset
+	menu       menu
+}
+
+func newMainMenuScene(states *state.StateManager, difficulty *config.DifficultyPreset) *mainMenuScene {
+	s := &mainMenuScene{
+		states:     states,
+		difficulty: difficulty,
+		menu:       menu{title: "SHMUP", items: []string{"Start", "", "Options"}},
+	}
+	s.refreshDifficulty()
+	return s
+}
+
+func (s *mainMenuScene) refreshDifficulty() {
+	s.menu.items[difficultyItem] = "Difficulty: " + s.difficulty.Name
+}
+
+func (s *mainMenuScene) State() state.GameState { return state.StateMainMenu }
+
+func (s *mainMenuScene) Enter(from state.GameState) error {
+	s.menu.cursor = 0
+	return nil
+}
+
+func (s *mainMenuScene) HandleInput(command string) {
+	action, held := input.Parse(command)
+	if held {
+		return
+	}
+	if s.menu.cursor == difficultyItem && (action == input.MoveLeft || action == input.MoveRight) {
+		action = input.Confirm
+	}
+	item, ok := s.menu.handleInput(action)
+	if !ok {
+		return
+	}
+	switch {
+	case s.menu.cursor == difficultyItem:
+		*s.difficulty = config.NextDifficulty(*s.difficulty)
+		s.refreshDifficulty()
+	case item == "Start":
+		s.states.Replace(state.StatePlaying)
+	case item == "Options":
+		s.states.Push(state.StateOptions)
+	}
+}
+
+func (s *mainMenuScene) Update(deltaTime float64) error {
+	s.menu.endTick()
+	return nil
+}
+
+func (s *mainMenuScene) Draw(screen types.Screen) {
+	s.menu.draw(screen, 40, 40)
+}
+
+type playingScene struct {
+	state.BaseScene
+	states       *state.StateManager
+	player       *entity.Player
+	renderSystem *system.RenderSystem
+	newRun       func()
+}
+
+func newPlayingScene(states *state.StateManager, player *entity.Player, renderSystem *system.RenderSystem, newRun func()) *playingScene {
+	return &playingScene{
+		states:       states,
+		player:       player,
+		renderSystem: renderSystem,
+		newRun:       newRun,
+	}
+}
+
+func (s *playingScene) State() state.GameState { return state.StatePlaying }
+
+// Enter démarre une nouvelle partie depuis le menu; un continue reprend la partie en cours
+func (s *playingScene) Enter(from state.GameState) error {
+	if from == state.StateMainMenu {
+		s.newRun()
+	}
+	return nil
+}
+
+func (s *playingScene) Systems() []string { return gameplaySystems }
+
+// directions des actions de déplacement maintenues
+var moveDirections = map[input.Action]types.Vector2D{
+	input.MoveUp:    {Y: -1},
+	input.MoveDown:  {Y: 1},
+	input.MoveLeft:  {X: -1},
+	input.MoveRight: {X: 1},
+}
+
+func (s *playingScene) HandleInput(command string) {
+	action, held := input.Parse(command)
+	if held {
+		if direction, ok := moveDirections[action]; ok {
+			s.player.Steer(direction)
+		} else if action == input.Focus {
+			s.player.Focus()
+		} else if action == input.Fire {
+			s.player.HoldTrigger()
+		}
+		return
+	}
+	switch action {
+	case input.Pause, input.Back:
+		s.states.Push(state.StatePaused)
+	case input.Fire:
+		s.player.PressTrigger()
+	}
+}
+
+func (s *playingScene) Update(deltaTime float64) error {
+	if !s.player.IsAlive() {
+		s.states.Replace(state.StateGameOver)
+	}
+	return nil
+}
+
+func (s *playingScene) Draw(screen types.Screen) {
+	s.renderSystem.Render(screen)
+}
+
+// pausedScene ne possède aucun système de jeu: le Scheduler les fige
+// et la Simulation suspend les timers d'événements jusqu'à sa sortie
+type pausedScene struct {
+	state.BaseScene
+	states  *state.StateManager
+	playing *playingScene
+	menu    menu
+}
+
+func newPausedScene(states *state.StateManager, playing *playingScene) *pausedScene {
+	return &pausedScene{
+		states:  states,
+		playing: playing,
+		menu:    menu{title: "PAUSED", items: []string{"Resume", "Options", "Main Menu"}},
+	}
+}
+
+func (s *pausedScene) State() state.GameState { return state.StatePaused }
+
+func (s *pausedScene) Enter(from state.GameState) error {
+	s.menu.cursor = 0
+	return nil
+}
+
+func (s *pausedScene) HandleInput(command string) {
+	action, held := input.Parse(command)
+	if held {
+		return
+	}
+	if action == input.Pause || action == input.Back {
+		s.states.Pop()
+		return
+	}
+	switch item, _ := s.menu.handleInput(action); item {
+	case "Resume":
+		s.states.Pop()
+	case "Options":
+		s.states.Push(state.StateOptions)
+	case "Main Menu":
+		s.states.Pop()
+		s.states.Replace(state.StateMainMenu)
+	}
+}
+
+func (s *pausedScene) Update(deltaTime float64) error {
+	s.menu.endTick()
+	return nil
+}
+
+func (s *pausedScene) Draw(screen types.Screen) {
+	s.playing.Draw(screen)
+	s.menu.draw(screen, 40, 40)
+}
+
+// optionsScene liste les actions avec leurs touches. Valider une action
+// attend la prochaine touche pressée et l'enregistre dans bindingsFile,
+// comme l'autofire. Les touches ne se changent que si la source d'entrée
+// est input.Rebindable (le clavier de game); sinon elles sont seulement listées.
+type optionsScene struct {
+	state.BaseScene
+	states       *state.StateManager
+	keys         input.Rebindable
+	bindings     input.Bindings
+	player       *entity.Player
+	bindingsFile string
+	menu         menu
+	capturing    bool
+	status       string
+}
+
+func newOptionsScene(states *state.StateManager, source input.InputSource, bindings input.Bindings, player *entity.Player, bindingsFile string) *optionsScene {
+	keys, _ := source.(input.Rebindable)
+	return &optionsScene{
+		states:       states,
+		keys:         keys,
+		bindings:     bindings,
+		player:       player,
+		bindingsFile: bindingsFile,
+		menu:         menu{title: "OPTIONS"},
+	}
+}
+
+func (s *optionsScene) State() state.GameState { return state.StateOptions }
+
+func (s *optionsScene) Enter(from state.GameState) error {
+	s.menu.cursor = 0
+	s.status = ""
+	s.refresh()
+	return nil
+}
+
+func (s *optionsScene) refresh() {
+	bindings := s.bindings
+	if s.keys != nil {
+		bindings = s.keys.Bindings()
+	}
+	s.menu.items = s.menu.items[:0]
+	for _, action := range input.Actions() {
+		s.menu.items = append(s.menu.items, fmt.Sprintf("%-10s %s", action.Label(), strings.Join(bindings[action], ", ")))
+	}
+	autofire := "Off"
+	if s.player.Autofire() {
+		autofire = "On"
+	}
+	s.menu.items = append(s.menu.items, fmt.Sprintf("%-10s %s", "Autofire", autofire), "Back")
+}
+
+func (s *optionsScene) HandleInput(command string) {
+	action, held := input.Parse(command)
+	if held || s.capturing {
+		return
+	}
+	if action == input.Back {
+		s.states.Pop()
+		return
+	}
+	item, ok := s.menu.handleInput(action)
+	if !ok {
+		return
+	}
+	if item == "Back" {
+		s.states.Pop()
+		return
+	}
+	if s.menu.cursor == len(input.Actions()) {
+		s.toggleAutofire()
+		return
+	}
+	if s.keys == nil {
+		s.status = "Keys can only be changed with a keyboard"
+		return
+	}
+	target := input.Actions()[s.menu.cursor]
+	s.capturing = true
+	s.status = fmt.Sprintf("Press a key for %s, Escape to cancel", target.Label())
+	s.keys.CaptureNextKey(func(key string) {
+		s.capturing = false
+		s.rebind(target, key)
+	})
+}
+
+func (s *optionsScene) rebind(action input.Action, key string) {
+	if key == "" {
+		s.status = ""
+		return
+	}
+	bindings := s.keys.Bindings().Rebind(action, key)
+	if err := s.keys.SetBindings(bindings); err != nil {
+		s.status = err.Error()
+		return
+	}
+	s.refresh()
+	s.save("bindings", bindings)
+}
+
+func (s *optionsScene) toggleAutofire() {
+	on := !s.player.Autofire()
+	s.player.SetAutofire(on)
+	s.refresh()
+	s.save("autofire", on)
+}
+
+func (s *optionsScene) save(key string, value interface{}) {
+	if s.bindingsFile == "" {
+		s.status = "Changed for this session only"
+		return
+	}
+	if err := config.SaveSetting(s.bindingsFile, key, value); err != nil {
+		s.status = err.Error()
+		return
+	}
+	s.status = "Saved to " + s.bindingsFile
+}
+
+func (s *optionsScene) Update(deltaTime float64) error {
+	s.menu.endTick()
+	return nil
+}
+
+func (s *optionsScene) Draw(screen types.Screen) {
+	s.menu.draw(screen, 40, 40)
+	screen.DebugPrintAt(s.status, 40, 40+20*(len(s.menu.items)+2))
+}
+
+var (
+	_ state.Scene      = (*mainMenuScene)(nil)
+	_ state.Scene      = (*playingScene)(nil)
+	_ state.Scene      = (*pausedScene)(nil)
+	_ state.Scene      = (*optionsScene)(nil)
+	_ types.Renderable = (*pausedScene)(nil)
+)
@@ -0,0 +1,363 @@
+// Package sim assemble les systèmes, managers et scènes d'une partie et les
+// fait avancer par pas fixes. Il n'importe pas ebiten: le package game y
+// branche la fenêtre, le rendu et le clavier, cmd/headless s'en passe.
+package sim
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/entity"
+	"github.com/ajkula/shmup/event"
+	"github.com/ajkula/shmup/input"
+	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/manager"
+	"github.com/ajkula/shmup/replay"
+	"github.com/ajkula/shmup/state"
+	"github.com/ajkula/shmup/system"
+	"github.com/ajkula/shmup/types"
+)
+
+// system names used in the registry
+const (
+	EventManagerName    = "EventManager"
+	StateManagerName    = "StateManager"
+	InputSystemName     = "InputSystem"
+	GameClockName       = "GameClock"
+	UpdateSystemName    = "UpdateSystem"
+	EnemyManagerName    = "EnemyManager"
+	BulletManagerName   = "BulletManager"
+	CollisionSystemName = "CollisionSystem"
+	ScoreManagerName    = "ScoreManager"
+	LevelManagerName    = "LevelManager"
+	RunStatsManagerName = "RunStatsManager"
+	RankManagerName     = "RankManager"
+	DebugHUDName        = "DebugHUD"
+	ConfigReloaderName  = "ConfigReloader"
+	RenderSystemName    = "RenderSystem"
+	RecorderName        = "Recorder"
+)
+
+type Simulation struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	eventManager interfaces.EventManagerInterface
+	states       *state.StateManager
+	registry     *core.Registry
+	scheduler    *core.Scheduler
+	player       *entity.Player
+	highScores   *manager.HighScoreTable
+	cfg          config.GameConfig
+	difficulty   config.DifficultyPreset
+	hud          *debugHUD
+}
+
+type registration struct {
+	name   string
+	system core.System
+	deps   []core.Dependency
+}
+
+type options struct {
+	cfg          config.GameConfig
+	dispatchMode *event.DispatchMode
+	recorder     io.Writer
+	initialState state.GameState
+	watcher      *config.Watcher
+	bindingsFile string
+	inputSource  input.InputSource
+}
+
+type Option func(o *options)
+
+// WithConfig injecte la config de la partie; sans elle, le global config.Config est utilisé
+func WithConfig(cfg config.GameConfig) Option {
+	return func(o *options) {
+		o.cfg = cfg
+	}
+}
+
+// WithDispatchMode prime sur SyncEventDispatch de la config
+func WithDispatchMode(mode event.DispatchMode) Option {
+	return func(o *options) {
+		o.dispatchMode = &mode
+	}
+}
+
+// WithRecorder enregistre tous les événements de la partie dans w, au format JSONL
+func WithRecorder(w io.Writer) Option {
+	return func(o *options) {
+		o.recorder = w
+	}
+}
+
+// WithInitialState choisit la première scène empilée, le menu principal par défaut
+func WithInitialState(initial state.GameState) Option {
+	return func(o *options) {
+		o.initialState = initial
+	}
+}
+
+// WithConfigWatcher recharge les valeurs live de la config quand le fichier surveillé change
+func WithConfigWatcher(watcher *config.Watcher) Option {
+	return func(o *options) {
+		o.watcher = watcher
+	}
+}
+
+// WithBindingsFile désigne le fichier de config où l'écran d'options enregistre les touches
+func WithBindingsFile(path string) Option {
+	return func(o *options) {
+		o.bindingsFile = path
+	}
+}
+
+// WithInputSource pilote la partie depuis source. Sans elle aucune entrée n'est
+// lue; une source input.Rebindable permet de changer les touches dans les options.
+func WithInputSource(source input.InputSource) Option {
+	return func(o *options) {
+		o.inputSource = source
+	}
+}
+
+func NewSimulation(ctx context.Context, opts ...Option) (*Simulation, error) {
+	o := options{cfg: config.Config, initialState: state.StateMainMenu}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	dispatchMode := event.DispatchAsync
+	if o.cfg.SyncEventDispatch {
+		dispatchMode = event.DispatchPerTick
+	}
+	if o.dispatchMode != nil {
+		dispatchMode = *o.dispatchMode
+	}
+
+	// les systèmes lisent la config de la partie via config.FromContext
+	gameCtx, cancel := context.WithCancel(config.WithContext(ctx, o.cfg))
+
+	eventManager := event.NewEventManager(event.WithDispatchMode(dispatchMode), event.WithQueueSize(o.cfg.MaxEventQueueSize))
+	if em, ok := eventManager.(*event.EventManager); ok {
+		if err := installDebugMiddlewares(em); err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to install event middlewares: %w", err)
+		}
+	}
+	if err := eventManager.Initialize(gameCtx); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to initialize event manager: %w", err)
+	}
+	stateManager := state.NewStateManager(eventManager)
+
+	s := &Simulation{
+		ctx:          gameCtx,
+		cancel:       cancel,
+		eventManager: eventManager,
+		states:       stateManager,
+		registry:     core.NewRegistry(),
+		highScores:   manager.NewHighScoreTable(manager.DefaultHighScoreCapacity),
+		cfg:          o.cfg,
+		difficulty:   o.cfg.DifficultyPreset(),
+	}
+
+	// initialize systems
+	renderSystem := system.NewRenderSystem()
+	collisionSystem := system.NewCollisionSystem(eventManager)
+	var inputOpts []system.InputOption
+	if o.inputSource != nil {
+		inputOpts = append(inputOpts, system.WithInputSource(o.inputSource))
+	}
+	inputSystem := system.NewInputSystem(eventManager, inputOpts...)
+	updateSystem := system.NewUpdateSystem(eventManager)
+	gameClock := system.NewGameClock()
+
+	// initialize managers
+	// le LevelManager fixe la difficulté des ennemis et des balles ennemies créés en jeu
+	levelManager := manager.NewLevelManager(eventManager)
+	enemyManager := manager.NewEnemyManager(eventManager, manager.WithEnemyConfig(o.cfg), manager.WithSpawner(levelManager))
+	bulletManager := manager.NewBulletManager(eventManager, manager.WithBulletConfig(o.cfg), manager.WithBulletScaling(levelManager.Scaling))
+	scoreManager := manager.NewScoreManager(eventManager)
+	runStatsManager := manager.NewRunStatsManager(eventManager)
+	rankManager := manager.NewRankManager(eventManager)
+
+	// create player
+	s.player = entity.NewPlayer(
+		types.Vector2D{
+			X: float64(o.cfg.ScreenWidth / 2),
+			Y: float64(o.cfg.ScreenHeight - 50),
+		},
+		eventManager,
+		entity.WithConfig(o.cfg),
+	)
+	updateSystem.AddEntity(s.player)
+	renderSystem.AddEntity(s.player)
+
+	// scenes
+	gameOver := newGameOverScene(stateManager, o.cfg, s.player, scoreManager, levelManager, runStatsManager, s.highScores)
+	newRun := func() {
+		s.player.Revive()
+		scoreManager.ResetScore()
+		levelManager.Reset()
+		runStatsManager.Reset()
+		rankManager.Reset()
+		gameOver.resetCredits()
+		scoreManager.SetMultiplier(s.difficulty.ScoreMultiplier)
+		levelManager.SetDifficulty(s.difficulty)
+	}
+	playing := newPlayingScene(stateManager, s.player, renderSystem, newRun)
+	stateManager.RegisterScene(newMainMenuScene(stateManager, &s.difficulty))
+	stateManager.RegisterScene(playing)
+	stateManager.RegisterScene(newPausedScene(stateManager, playing))
+	stateManager.RegisterScene(gameOver)
+	stateManager.RegisterScene(newHighScoreEntryScene(stateManager, scoreManager, levelManager, s.highScores))
+	stateManager.RegisterScene(newOptionsScene(stateManager, o.inputSource, o.cfg.Bindings, s.player, o.bindingsFile))
+	// les timers suivent le filtre du Scheduler: figés avec les systèmes de jeu
+	s.syncTimers()
+	stateManager.OnTransition(func(state.StateChange) { s.syncTimers() })
+	stateManager.Push(o.initialState)
+
+	// register all systems and managers with their update order
+	registrations := []registration{
+		{EventManagerName, eventManager, nil},
+		{StateManagerName, stateManager, []core.Dependency{core.RunsAfter(EventManagerName)}},
+		// les actions d'un tick sont distribuées dans ce tick, comme à l'enregistrement
+		{InputSystemName, inputSystem, []core.Dependency{core.RunsBefore(EventManagerName)}},
+		{GameClockName, gameClock, []core.Dependency{core.RunsAfter(StateManagerName)}},
+		{UpdateSystemName, updateSystem, []core.Dependency{core.RunsAfter(GameClockName)}},
+		{EnemyManagerName, enemyManager, []core.Dependency{core.RunsAfter(UpdateSystemName)}},
+		{BulletManagerName, bulletManager, []core.Dependency{core.RunsAfter(UpdateSystemName)}},
+		{CollisionSystemName, collisionSystem, []core.Dependency{core.RunsAfter(EnemyManagerName, BulletManagerName)}},
+		{ScoreManagerName, scoreManager, []core.Dependency{core.RunsAfter(CollisionSystemName)}},
+		{RankManagerName, rankManager, []core.Dependency{core.RunsAfter(CollisionSystemName)}},
+		{LevelManagerName, levelManager, []core.Dependency{core.RunsAfter(ScoreManagerName, RankManagerName)}},
+		{RunStatsManagerName, runStatsManager, []core.Dependency{core.RunsAfter(CollisionSystemName)}},
+		{RenderSystemName, renderSystem, []core.Dependency{core.RunsAfter(LevelManagerName)}},
+	}
+	if o.recorder != nil {
+		registrations = append(registrations, registration{RecorderName, replay.NewRecorder(eventManager, o.recorder), []core.Dependency{core.RunsAfter(EventManagerName), core.RunsBefore(StateManagerName)}})
+	}
+	if debugBuild {
+		s.hud = newDebugHUD(eventManager)
+		registrations = append(registrations, registration{DebugHUDName, s.hud, []core.Dependency{core.RunsAfter(EventManagerName)}})
+	}
+	if o.watcher != nil {
+		registrations = append(registrations, registration{ConfigReloaderName, system.NewConfigReloader(eventManager, o.watcher, o.cfg), []core.Dependency{core.RunsBefore(EventManagerName)}})
+	}
+	for _, r := range registrations {
+		if err := s.registry.Register(r.name, r.system, r.deps...); err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to register system: %w", err)
+		}
+	}
+
+	scheduler, err := core.NewSchedulerFromRegistry(s.registry)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to order systems: %w", err)
+	}
+	s.scheduler = scheduler
+	s.scheduler.SetFilter(s.ticks)
+
+	// initialize all systems, sauf l'EventManager déjà initialisé plus haut:
+	// les constructeurs s'abonnent et publient avant l'enregistrement
+	for _, sys := range s.scheduler.Systems() {
+		if sys == eventManager {
+			continue
+		}
+		if err := sys.Initialize(gameCtx); err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to initialize system: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// ticks indique si le système doit être exécuté avec la scène courante
+func (s *Simulation) ticks(name string) bool {
+	if alwaysTicked[name] {
+		return true
+	}
+	scene := s.states.Current()
+	if scene == nil {
+		return false
+	}
+	for _, owned := range scene.Systems() {
+		if owned == name {
+			return true
+		}
+	}
+	return false
+}
+
+// syncTimers suspend les timers d'événements tant que la scène courante ne
+// fait pas tourner les systèmes de jeu: pause, menus, options et game over
+func (s *Simulation) syncTimers() {
+	if s.ticks(GameClockName) {
+		s.eventManager.ResumeTimers()
+	} else {
+		s.eventManager.PauseTimers()
+	}
+}
+
+// Draw dessine la scène courante et, dans les builds debug, le HUD
+func (s *Simulation) Draw(screen types.Screen) {
+	if scene, ok := s.states.Current().(types.Renderable); ok {
+		scene.Draw(screen)
+	}
+	if s.hud != nil {
+		s.hud.Draw(screen)
+	}
+}
+
+// Tick avance la simulation d'un pas fixe, indépendamment de l'horloge murale
+func (s *Simulation) Tick() error {
+	return s.scheduler.Tick(core.FixedDeltaTime)
+}
+
+// System renvoie le système enregistré sous ce nom
+func (s *Simulation) System(name string) (core.System, bool) {
+	return s.registry.Get(name)
+}
+
+func (s *Simulation) States() *state.StateManager {
+	return s.states
+}
+
+// Config renvoie la config injectée dans cette partie
+func (s *Simulation) Config() config.GameConfig {
+	return s.cfg
+}
+
+// Difficulty renvoie le preset choisi au menu, appliqué à chaque nouvelle partie
+func (s *Simulation) Difficulty() config.DifficultyPreset {
+	return s.difficulty
+}
+
+func (s *Simulation) HighScores() *manager.HighScoreTable {
+	return s.highScores
+}
+
+func (s *Simulation) Registry() *core.Registry {
+	return s.registry
+}
+
+// Context est annulé par Stop ou Shutdown
+func (s *Simulation) Context() context.Context {
+	return s.ctx
+}
+
+// Stop annule le contexte des systèmes sans les libérer
+func (s *Simulation) Stop() {
+	s.cancel()
+}
+
+func (s *Simulation) Shutdown() {
+	s.cancel()
+	for _, sys := range s.scheduler.Systems() {
+		sys.Shutdown()
+	}
+}
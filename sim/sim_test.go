@@ -0,0 +1,185 @@
+package sim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/entity"
+	"github.com/ajkula/shmup/event"
+	"github.com/ajkula/shmup/input"
+	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/manager"
+	"github.com/ajkula/shmup/state"
+	"github.com/ajkula/shmup/types"
+)
+
+var timerFired = interfaces.RegisterEventType("SimTestTimerFired", "timer scheduled by the sim tests")
+
+func TestTimersFreezeOutsideGameplay(t *testing.T) {
+	s, err := NewSimulation(context.Background(), WithConfig(config.Default()), WithDispatchMode(event.DispatchPerTick))
+	if err != nil {
+		t.Fatalf("NewSimulation returned an error: %v", err)
+	}
+	defer s.Shutdown()
+	fired, _ := s.eventManager.Subscribe(timerFired)
+
+	tick := func(n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			if err := s.Tick(); err != nil {
+				t.Fatalf("Tick returned an error: %v", err)
+			}
+		}
+	}
+
+	s.eventManager.PublishAfter(interfaces.Ticks(2), timerFired, nil)
+	tick(10)
+	if len(fired) != 0 {
+		t.Fatal("Timers should not run in the main menu")
+	}
+
+	s.States().Replace(state.StatePlaying)
+	tick(5)
+	if len(fired) != 1 {
+		t.Fatalf("Timer should fire once gameplay runs, got %d events", len(fired))
+	}
+	<-fired
+
+	// pause empilée sur la partie, game over à sa place
+	states := s.States()
+	for _, c := range []struct {
+		frozen       state.GameState
+		enter, leave func()
+	}{
+		{state.StatePaused, func() { states.Push(state.StatePaused) }, states.Pop},
+		{state.StateGameOver, func() { states.Replace(state.StateGameOver) }, func() { states.Replace(state.StatePlaying) }},
+	} {
+		s.eventManager.PublishAfter(interfaces.Ticks(2), timerFired, nil)
+		c.enter()
+		tick(10)
+		if len(fired) != 0 {
+			t.Fatalf("Timers should not run in %v", c.frozen)
+		}
+
+		c.leave()
+		tick(5)
+		if len(fired) != 1 {
+			t.Fatalf("Timer should resume after %v, got %d events", c.frozen, len(fired))
+		}
+		<-fired
+	}
+}
+
+func TestHeldFireBulletsSurvive(t *testing.T) {
+	script := make(map[uint64][]string)
+	for tick := uint64(1); tick <= 40; tick++ {
+		script[tick] = []string{input.Fire.Held()}
+	}
+	s, err := NewSimulation(context.Background(),
+		WithConfig(config.Default()),
+		WithDispatchMode(event.DispatchPerTick),
+		WithInitialState(state.StatePlaying),
+		WithInputSource(input.NewScripted(script)))
+	if err != nil {
+		t.Fatalf("NewSimulation returned an error: %v", err)
+	}
+	defer s.Shutdown()
+	created, _ := s.eventManager.Subscribe(interfaces.BulletCreated)
+	destroyed, _ := s.eventManager.Subscribe(interfaces.BulletDestroyed)
+
+	for i := 0; i < 50; i++ {
+		if err := s.Tick(); err != nil {
+			t.Fatalf("Tick returned an error: %v", err)
+		}
+	}
+
+	// tirs aux ticks 1, 13, 25 et 37: les balles se suivent de près sans se détruire
+	if len(created) != 4 {
+		t.Fatalf("Expected 4 bullets while holding fire, got %d", len(created))
+	}
+	if len(destroyed) != 0 {
+		t.Errorf("Player bullets should survive, %d were destroyed", len(destroyed))
+	}
+	bullets, _ := s.System(BulletManagerName)
+	if count := bullets.(*manager.BulletManager).GetBulletCount(); count != 4 {
+		t.Errorf("Expected 4 live bullets, got %d", count)
+	}
+}
+
+func TestPadSelectsOncePerPress(t *testing.T) {
+	// le bouton A est lié à Fire et à Confirm: un appui publie les deux actions
+	press := []string{input.Fire.Pressed(), input.Confirm.Pressed()}
+	s, err := NewSimulation(context.Background(),
+		WithConfig(config.Default()),
+		WithDispatchMode(event.DispatchPerTick),
+		WithInputSource(input.NewScripted(map[uint64][]string{
+			// le menu principal n'est empilé qu'à la fin du tick 1
+			2: {input.MoveDown.Pressed()},
+			4: press,
+			6: press,
+		})))
+	if err != nil {
+		t.Fatalf("NewSimulation returned an error: %v", err)
+	}
+	defer s.Shutdown()
+
+	start := s.Difficulty()
+	for i := 0; i < 5; i++ {
+		if err := s.Tick(); err != nil {
+			t.Fatalf("Tick returned an error: %v", err)
+		}
+	}
+	if want := config.NextDifficulty(start); s.Difficulty().Name != want.Name {
+		t.Fatalf("One press should move the difficulty from %s to %s, got %s", start.Name, want.Name, s.Difficulty().Name)
+	}
+	for i := 0; i < 2; i++ {
+		s.Tick()
+	}
+	if want := config.NextDifficulty(config.NextDifficulty(start)); s.Difficulty().Name != want.Name {
+		t.Errorf("A second press on a later tick should select again, got %s", s.Difficulty().Name)
+	}
+}
+
+func TestMenuDifficultyReachesSpawnedEnemies(t *testing.T) {
+	// Normal -> Hard -> Lunatic, puis retour sur Start
+	s, err := NewSimulation(context.Background(),
+		WithConfig(config.Default()),
+		WithDispatchMode(event.DispatchPerTick),
+		WithInputSource(input.NewScripted(map[uint64][]string{
+			2:  {input.MoveDown.Pressed()},
+			4:  {input.Confirm.Pressed()},
+			6:  {input.Confirm.Pressed()},
+			8:  {input.MoveUp.Pressed()},
+			10: {input.Confirm.Pressed()},
+		})))
+	if err != nil {
+		t.Fatalf("NewSimulation returned an error: %v", err)
+	}
+	defer s.Shutdown()
+	created, _ := s.eventManager.Subscribe(interfaces.EnemyCreated)
+	bullets, _ := s.eventManager.Subscribe(interfaces.BulletCreated)
+
+	for i := 0; i < 240 && (len(created) == 0 || len(bullets) == 0); i++ {
+		if err := s.Tick(); err != nil {
+			t.Fatalf("Tick returned an error: %v", err)
+		}
+	}
+	if s.Difficulty().Name != "Lunatic" || s.States().GetState() != state.StatePlaying {
+		t.Fatalf("Expected a Lunatic run, got %s in %v", s.Difficulty().Name, s.States().GetState())
+	}
+	if len(created) == 0 || len(bullets) == 0 {
+		t.Fatal("An enemy should have spawned and fired")
+	}
+
+	lunatic, _ := config.LookupDifficulty("Lunatic")
+	eventManager := s.eventManager
+	want := entity.NewEnemy(types.Vector2D{}, eventManager, entity.WithConfig(config.Default()), entity.WithDifficulty(lunatic))
+	if enemy := (<-created).Data.(*entity.Enemy); enemy.GetHealth() != want.GetHealth() {
+		t.Errorf("Spawned enemy health: got %d, want %d for Lunatic", enemy.GetHealth(), want.GetHealth())
+	}
+	wantBullet := entity.NewBullet(0, 0, true, eventManager, entity.WithConfig(config.Default()), entity.WithDifficulty(lunatic))
+	if bullet := (<-bullets).Data.(*entity.Bullet); bullet.Speed != wantBullet.Speed {
+		t.Errorf("Enemy bullet speed: got %v, want %v for Lunatic", bullet.Speed, wantBullet.Speed)
+	}
+}
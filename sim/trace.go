@@ -0,0 +1,11 @@
+//go:build !debug
+
+package sim
+
+import "github.com/ajkula/shmup/event"
+
+const debugBuild = false
+
+func installDebugMiddlewares(em *event.EventManager) error {
+	return nil
+}
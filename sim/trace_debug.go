@@ -0,0 +1,16 @@
+//go:build debug
+
+package sim
+
+import (
+	"os"
+
+	"github.com/ajkula/shmup/event"
+)
+
+const debugBuild = true
+
+// installDebugMiddlewares trace chaque événement sur stderr dans les builds -tags debug
+func installDebugMiddlewares(em *event.EventManager) error {
+	return em.Use("trace", 0, event.TraceLogger(os.Stderr))
+}
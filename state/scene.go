@@ -0,0 +1,29 @@
+package state
+
+// Scene est un écran du jeu empilé dans le StateManager.
+// Seule la scène du sommet reçoit les entrées et est mise à jour;
+// Systems liste les systèmes du Scheduler à exécuter tant qu'elle est au sommet.
+// Les scènes qui implémentent types.Renderable sont dessinées par le jeu.
+type Scene interface {
+	State() GameState
+	// Enter est appelée quand la scène arrive au sommet par Push ou Replace
+	Enter(from GameState) error
+	// Exit est appelée quand la scène quitte la pile par Pop ou Replace
+	Exit(to GameState) error
+	HandleInput(command string)
+	Update(deltaTime float64) error
+	Systems() []string
+}
+
+// BaseScene fournit des hooks vides à embarquer dans les scènes
+type BaseScene struct{}
+
+func (BaseScene) Enter(from GameState) error { return nil }
+
+func (BaseScene) Exit(to GameState) error { return nil }
+
+func (BaseScene) HandleInput(command string) {}
+
+func (BaseScene) Update(deltaTime float64) error { return nil }
+
+func (BaseScene) Systems() []string { return nil }
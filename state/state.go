@@ -3,8 +3,10 @@ package state
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/event"
 	"github.com/ajkula/shmup/interfaces"
 )
 
@@ -15,48 +17,144 @@ const (
 	StatePlaying
 	StatePaused
 	StateGameOver
+	StateOptions
+	StateHighScoreEntry
 )
 
+func (s GameState) String() string {
+	switch s {
+	case StateMainMenu:
+		return "MainMenu"
+	case StatePlaying:
+		return "Playing"
+	case StatePaused:
+		return "Paused"
+	case StateGameOver:
+		return "GameOver"
+	case StateOptions:
+		return "Options"
+	case StateHighScoreEntry:
+		return "HighScoreEntry"
+	default:
+		return fmt.Sprintf("GameState(%d)", int(s))
+	}
+}
+
+type Transition int
+
+const (
+	TransitionPush Transition = iota
+	TransitionPop
+	TransitionReplace
+)
+
+func (t Transition) String() string {
+	switch t {
+	case TransitionPush:
+		return "push"
+	case TransitionPop:
+		return "pop"
+	case TransitionReplace:
+		return "replace"
+	default:
+		return fmt.Sprintf("Transition(%d)", int(t))
+	}
+}
+
+// StateChange est publié après chaque transition de la pile de scènes
+type StateChange struct {
+	Transition Transition
+	From       GameState
+	To         GameState
+	Depth      int
+}
+
+var (
+	// ChangeTopic demande le remplacement de la scène courante
+	ChangeTopic = event.NewTopic[GameState](interfaces.GameStateChangeEvent)
+
+	SceneTransition      = interfaces.RegisterEventType("SceneTransition", "scene stack push, pop or replace was applied")
+	SceneTransitionTopic = event.NewTopic[StateChange](SceneTransition)
+)
+
+type request struct {
+	transition Transition
+	state      GameState
+}
+
+// StateManager gère la pile de scènes. Les transitions demandées pendant un tick
+// sont appliquées dans l'ordre à la fin de son Update.
 type StateManager struct {
 	core.BaseSystem
-	currentState    GameState
-	eventManager    interfaces.EventManagerInterface
-	stateChangeChan chan GameState
+	eventManager interfaces.EventManagerInterface
+	scenes       map[GameState]Scene
+	stack        []Scene
+	pending      []request
+	hooks        []func(StateChange)
+	stateChan    <-chan interfaces.Event
+	inputChan    <-chan interfaces.Event
+	mu           sync.Mutex
 }
 
 func NewStateManager(eventManager interfaces.EventManagerInterface) *StateManager {
 	return &StateManager{
-		currentState:    StateMainMenu,
-		eventManager:    eventManager,
-		stateChangeChan: make(chan GameState, 10),
+		eventManager: eventManager,
+		scenes:       make(map[GameState]Scene),
+		stack:        make([]Scene, 0),
 	}
 }
 
 func (sm *StateManager) Initialize(ctx context.Context) error {
 	sm.CTX = ctx
-	return nil
-}
-
-func (sm *StateManager) Run(ctx context.Context) error {
-	sm.CTX = ctx
-	stateChan, err := sm.eventManager.Subscribe(interfaces.GameStateChangeEvent)
+	stateChan, err := ChangeTopic.Subscribe(sm.eventManager)
 	if err != nil {
 		return fmt.Errorf("failed to subscribe to GameStateChangeEvent: %w", err)
 	}
-	defer sm.eventManager.Unsubscribe(interfaces.GameStateChangeEvent, stateChan)
-
-	for {
-		select {
-		case <-sm.CTX.Done():
-			return sm.CTX.Err()
-		case newState := <-sm.stateChangeChan:
-			sm.setState(newState)
-		case evt := <-stateChan:
-			if newState, ok := evt.Data.(GameState); ok {
-				sm.stateChangeChan <- newState
-			}
-		}
+	inputChan, err := sm.eventManager.Subscribe(interfaces.InputEvent)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to InputEvent: %w", err)
 	}
+	sm.stateChan = stateChan
+	sm.inputChan = inputChan
+	return nil
+}
+
+// RegisterScene rend une scène disponible pour Push et Replace
+func (sm *StateManager) RegisterScene(scene Scene) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.scenes[scene.State()] = scene
+}
+
+// OnTransition appelle hook après chaque transition appliquée, une fois la
+// scène d'arrivée entrée et avant la publication de SceneTransition
+func (sm *StateManager) OnTransition(hook func(StateChange)) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.hooks = append(sm.hooks, hook)
+}
+
+func (sm *StateManager) Push(state GameState) {
+	sm.request(TransitionPush, state)
+}
+
+func (sm *StateManager) Pop() {
+	sm.request(TransitionPop, 0)
+}
+
+func (sm *StateManager) Replace(state GameState) {
+	sm.request(TransitionReplace, state)
+}
+
+// RequestStateChange remplace la scène courante
+func (sm *StateManager) RequestStateChange(state GameState) {
+	sm.Replace(state)
+}
+
+func (sm *StateManager) request(transition Transition, state GameState) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.pending = append(sm.pending, request{transition: transition, state: state})
 }
 
 func (sm *StateManager) Update(deltaTime float64) error {
@@ -64,46 +162,160 @@ func (sm *StateManager) Update(deltaTime float64) error {
 	case <-sm.CTX.Done():
 		return sm.CTX.Err()
 	default:
-		// logique maj specifique etat actuel
-		switch sm.currentState {
-		case StatePlaying:
-			// logique maj etat de jeu
-		case StatePaused:
-			// logique maj etat en pause
+		sm.processEvents()
+		if scene := sm.Current(); scene != nil {
+			if err := scene.Update(deltaTime); err != nil {
+				return fmt.Errorf("scene %v: %w", scene.State(), err)
+			}
 		}
+		return sm.applyPending()
 	}
-	return nil
 }
 
-func (sm *StateManager) Shutdown() {
-	// cleanup
-	close(sm.stateChangeChan)
+func (sm *StateManager) processEvents() {
+	for {
+		select {
+		case evt := <-sm.stateChan:
+			if newState, ok := ChangeTopic.Payload(evt); ok {
+				sm.Replace(newState)
+			}
+		case evt := <-sm.inputChan:
+			command, ok := evt.Data.(string)
+			if !ok {
+				continue
+			}
+			if scene := sm.Current(); scene != nil {
+				scene.HandleInput(command)
+			}
+		default:
+			return
+		}
+	}
 }
 
-func (sm *StateManager) setState(state GameState) {
-	if sm.currentState == state {
-		return
+func (sm *StateManager) applyPending() error {
+	for {
+		sm.mu.Lock()
+		if len(sm.pending) == 0 {
+			sm.mu.Unlock()
+			return nil
+		}
+		req := sm.pending[0]
+		sm.pending = sm.pending[1:]
+		sm.mu.Unlock()
+
+		if err := sm.apply(req); err != nil {
+			return err
+		}
 	}
+}
 
-	sm.exitState(sm.currentState)
-	sm.currentState = state
-	sm.enterState(state)
+func (sm *StateManager) apply(req request) error {
+	sm.mu.Lock()
+	var from Scene
+	if len(sm.stack) > 0 {
+		from = sm.stack[len(sm.stack)-1]
+	}
+	var to Scene
+	if req.transition != TransitionPop {
+		scene, ok := sm.scenes[req.state]
+		if !ok {
+			sm.mu.Unlock()
+			return fmt.Errorf("no scene registered for state %v", req.state)
+		}
+		to = scene
+	} else if len(sm.stack) > 1 {
+		to = sm.stack[len(sm.stack)-2]
+	}
+	sm.mu.Unlock()
+
+	if req.transition == TransitionPop && from == nil {
+		return fmt.Errorf("cannot pop an empty scene stack")
+	}
+
+	change := StateChange{Transition: req.transition, From: stateOf(from), To: stateOf(to)}
+	if from != nil && req.transition != TransitionPush {
+		if err := from.Exit(change.To); err != nil {
+			return fmt.Errorf("failed to exit scene %v: %w", from.State(), err)
+		}
+	}
 
-	sm.eventManager.Publish(interfaces.GameStateChangeEvent, state)
+	sm.mu.Lock()
+	switch req.transition {
+	case TransitionPush:
+		sm.stack = append(sm.stack, to)
+	case TransitionPop:
+		sm.stack = sm.stack[:len(sm.stack)-1]
+	case TransitionReplace:
+		if len(sm.stack) > 0 {
+			sm.stack[len(sm.stack)-1] = to
+		} else {
+			sm.stack = append(sm.stack, to)
+		}
+	}
+	change.Depth = len(sm.stack)
+	sm.mu.Unlock()
+
+	if to != nil && req.transition != TransitionPop {
+		if err := to.Enter(change.From); err != nil {
+			return fmt.Errorf("failed to enter scene %v: %w", to.State(), err)
+		}
+	}
+
+	sm.mu.Lock()
+	hooks := sm.hooks
+	sm.mu.Unlock()
+	for _, hook := range hooks {
+		hook(change)
+	}
+
+	SceneTransitionTopic.Publish(sm.eventManager, change)
+	return nil
+}
+
+func stateOf(scene Scene) GameState {
+	if scene == nil {
+		return StateMainMenu
+	}
+	return scene.State()
+}
+
+// Current renvoie la scène au sommet de la pile, ou nil si elle est vide
+func (sm *StateManager) Current() Scene {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if len(sm.stack) == 0 {
+		return nil
+	}
+	return sm.stack[len(sm.stack)-1]
 }
 
 func (sm *StateManager) GetState() GameState {
-	return sm.currentState
+	return stateOf(sm.Current())
 }
 
-func (sm *StateManager) exitState(state GameState) {
-	// logique de sortie specifique à chaque etat
+// Stack renvoie les états empilés, du bas vers le sommet
+func (sm *StateManager) Stack() []GameState {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	states := make([]GameState, len(sm.stack))
+	for i, scene := range sm.stack {
+		states[i] = scene.State()
+	}
+	return states
 }
 
-func (sm *StateManager) enterState(state GameState) {
-	// logique entrée spécifique à chaque etat
+func (sm *StateManager) Run(ctx context.Context) error {
+	return sm.BaseSystem.Run(ctx)
 }
 
-func (sm *StateManager) RequestStateChange(state GameState) {
-	sm.stateChangeChan <- state
+func (sm *StateManager) Shutdown() {
+	if sm.stateChan != nil {
+		sm.eventManager.Unsubscribe(interfaces.GameStateChangeEvent, sm.stateChan)
+	}
+	if sm.inputChan != nil {
+		sm.eventManager.Unsubscribe(interfaces.InputEvent, sm.inputChan)
+	}
 }
+
+var _ core.System = (*StateManager)(nil)
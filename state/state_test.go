@@ -0,0 +1,180 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajkula/shmup/event"
+	"github.com/ajkula/shmup/interfaces"
+)
+
+type testScene struct {
+	BaseScene
+	state  GameState
+	hooks  *[]string
+	inputs []string
+}
+
+func (s *testScene) State() GameState { return s.state }
+
+func (s *testScene) Enter(from GameState) error {
+	*s.hooks = append(*s.hooks, "enter "+s.state.String()+" from "+from.String())
+	return nil
+}
+
+func (s *testScene) Exit(to GameState) error {
+	*s.hooks = append(*s.hooks, "exit "+s.state.String()+" to "+to.String())
+	return nil
+}
+
+func (s *testScene) HandleInput(command string) {
+	s.inputs = append(s.inputs, command)
+}
+
+func newTestStateManager(t *testing.T) (*StateManager, *event.EventManager, *[]string, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	em := event.NewEventManager(event.WithDispatchMode(event.DispatchPerTick)).(*event.EventManager)
+	if err := em.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+	sm := NewStateManager(em)
+	if err := sm.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+	hooks := &[]string{}
+	for _, state := range []GameState{StateMainMenu, StatePlaying, StatePaused} {
+		sm.RegisterScene(&testScene{state: state, hooks: hooks})
+	}
+	return sm, em, hooks, cancel
+}
+
+func TestStateManagerPushPopReplace(t *testing.T) {
+	sm, _, hooks, cancel := newTestStateManager(t)
+	defer cancel()
+
+	sm.Push(StateMainMenu)
+	sm.Replace(StatePlaying)
+	sm.Push(StatePaused)
+	if err := sm.Update(0); err != nil {
+		t.Fatal(err)
+	}
+	if got := sm.Stack(); len(got) != 2 || got[0] != StatePlaying || got[1] != StatePaused {
+		t.Fatalf("stack = %v, want [Playing Paused]", got)
+	}
+
+	sm.Pop()
+	if err := sm.Update(0); err != nil {
+		t.Fatal(err)
+	}
+	if sm.GetState() != StatePlaying {
+		t.Errorf("state = %v, want Playing", sm.GetState())
+	}
+
+	expected := []string{
+		"enter MainMenu from MainMenu",
+		"exit MainMenu to Playing",
+		"enter Playing from MainMenu",
+		"enter Paused from Playing",
+		"exit Paused to Playing",
+	}
+	if len(*hooks) != len(expected) {
+		t.Fatalf("hooks = %v, want %v", *hooks, expected)
+	}
+	for i := range expected {
+		if (*hooks)[i] != expected[i] {
+			t.Errorf("hook %d = %q, want %q", i, (*hooks)[i], expected[i])
+		}
+	}
+}
+
+func TestStateManagerPublishesTransitions(t *testing.T) {
+	sm, em, _, cancel := newTestStateManager(t)
+	defer cancel()
+	transitions, _ := SceneTransitionTopic.Subscribe(em)
+
+	sm.Push(StatePlaying)
+	sm.Push(StatePaused)
+	sm.Update(0)
+	em.Update(0)
+
+	var changes []StateChange
+	for len(transitions) > 0 {
+		if change, ok := SceneTransitionTopic.Payload(<-transitions); ok {
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) != 2 {
+		t.Fatalf("got %d transitions, want 2", len(changes))
+	}
+	last := changes[1]
+	if last.Transition != TransitionPush || last.From != StatePlaying || last.To != StatePaused || last.Depth != 2 {
+		t.Errorf("unexpected transition %+v", last)
+	}
+}
+
+func TestStateManagerTransitionHooks(t *testing.T) {
+	sm, _, hooks, cancel := newTestStateManager(t)
+	defer cancel()
+	sm.OnTransition(func(change StateChange) {
+		*hooks = append(*hooks, "hook "+change.Transition.String()+" to "+change.To.String()+" on "+sm.GetState().String())
+	})
+
+	sm.Push(StatePlaying)
+	sm.Push(StatePaused)
+	sm.Pop()
+	if err := sm.Update(0); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"enter Playing from MainMenu",
+		"hook push to Playing on Playing",
+		"enter Paused from Playing",
+		"hook push to Paused on Paused",
+		"exit Paused to Playing",
+		"hook pop to Playing on Playing",
+	}
+	if len(*hooks) != len(expected) {
+		t.Fatalf("hooks = %v, want %v", *hooks, expected)
+	}
+	for i := range expected {
+		if (*hooks)[i] != expected[i] {
+			t.Errorf("hook %d = %q, want %q", i, (*hooks)[i], expected[i])
+		}
+	}
+}
+
+func TestStateManagerRoutesInputAndEvents(t *testing.T) {
+	sm, em, _, cancel := newTestStateManager(t)
+	defer cancel()
+
+	sm.Push(StatePlaying)
+	sm.Update(0)
+
+	em.Publish(interfaces.InputEvent, "pause")
+	ChangeTopic.Publish(em, StateMainMenu)
+	em.Update(0)
+	sm.Update(0)
+
+	playing := sm.scenes[StatePlaying].(*testScene)
+	if len(playing.inputs) != 1 || playing.inputs[0] != "pause" {
+		t.Errorf("playing scene inputs = %v", playing.inputs)
+	}
+	if sm.GetState() != StateMainMenu {
+		t.Errorf("state = %v, want MainMenu after GameStateChangeEvent", sm.GetState())
+	}
+}
+
+func TestStateManagerRejectsUnknownScene(t *testing.T) {
+	sm, _, _, cancel := newTestStateManager(t)
+	defer cancel()
+
+	sm.Push(StateOptions)
+	if err := sm.Update(0); err == nil {
+		t.Error("expected an error for an unregistered scene")
+	}
+	sm.Pop()
+	if err := sm.Update(0); err == nil {
+		t.Error("expected an error when popping an empty stack")
+	}
+}
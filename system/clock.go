@@ -0,0 +1,61 @@
+package system
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ajkula/shmup/core"
+)
+
+// GameClock mesure le temps de jeu: il n'avance que lorsque le Scheduler
+// l'exécute, donc il s'arrête avec la pause contrairement au temps réel
+type GameClock struct {
+	core.BaseSystem
+	elapsed float64
+	ticks   uint64
+	mu      sync.Mutex
+}
+
+func NewGameClock() *GameClock {
+	return &GameClock{}
+}
+
+func (gc *GameClock) Initialize(ctx context.Context) error {
+	gc.CTX = ctx
+	return nil
+}
+
+func (gc *GameClock) Update(deltaTime float64) error {
+	select {
+	case <-gc.CTX.Done():
+		return gc.CTX.Err()
+	default:
+		gc.mu.Lock()
+		defer gc.mu.Unlock()
+		gc.elapsed += deltaTime
+		gc.ticks++
+		return nil
+	}
+}
+
+// Elapsed renvoie le temps de jeu écoulé, en secondes
+func (gc *GameClock) Elapsed() float64 {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	return gc.elapsed
+}
+
+// Ticks renvoie le nombre de pas fixes simulés
+func (gc *GameClock) Ticks() uint64 {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	return gc.ticks
+}
+
+func (gc *GameClock) Run(ctx context.Context) error {
+	return gc.BaseSystem.Run(ctx)
+}
+
+func (gc *GameClock) Shutdown() {
+	// cleanup
+}
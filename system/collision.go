@@ -3,7 +3,6 @@ package system
 import (
 	"context"
 	"sync"
-	"time"
 
 	"github.com/ajkula/shmup/core"
 	"github.com/ajkula/shmup/interfaces"
@@ -16,19 +15,17 @@ const (
 
 type CollisionSystem struct {
 	core.BaseSystem
-	collidables   []types.GameEntity
-	mu            sync.Mutex
-	accumulator   float64
-	lastCheckTime time.Time
-	eventManager  interfaces.EventManagerInterface
-	eventChannels map[interfaces.EventType]<-chan interfaces.Event
+	collidables  []types.GameEntity
+	mu           sync.Mutex
+	accumulator  float64
+	eventManager interfaces.EventManagerInterface
+	events       <-chan interfaces.Event
 }
 
 func NewCollisionSystem(eventManager interfaces.EventManagerInterface) *CollisionSystem {
 	return &CollisionSystem{
-		collidables:   make([]types.GameEntity, 0),
-		lastCheckTime: time.Now(),
-		eventManager:  eventManager,
+		collidables:  make([]types.GameEntity, 0),
+		eventManager: eventManager,
 	}
 }
 
@@ -41,21 +38,15 @@ func (cs *CollisionSystem) Initialize(ctx context.Context) error {
 	eventTypes := []interfaces.EventType{
 		interfaces.BulletCreated,
 		interfaces.BulletDestroyed,
+		interfaces.EnemyCreated,
 		interfaces.EnemyDestroyed,
 		interfaces.BossDefeated,
 		interfaces.PlayerDestroyed,
 	}
 
-	cs.eventChannels = make(map[interfaces.EventType]<-chan interfaces.Event)
-	for _, eventType := range eventTypes {
-		ch, err := cs.eventManager.Subscribe(eventType)
-		if err != nil {
-			return err
-		}
-		cs.eventChannels[eventType] = ch
-	}
-
-	return nil
+	// un seul canal: une création suivie d'une destruction arrive dans cet ordre
+	cs.events, err = cs.eventManager.SubscribeMany(eventTypes)
+	return err
 }
 
 func (cs *CollisionSystem) Update(deltaTime float64) error {
@@ -66,10 +57,8 @@ func (cs *CollisionSystem) Update(deltaTime float64) error {
 		cs.mu.Lock()
 		defer cs.mu.Unlock()
 
-		currentTime := time.Now()
-		actualDeltaTime := currentTime.Sub(cs.lastCheckTime).Seconds()
-		cs.lastCheckTime = currentTime
-		cs.accumulator += actualDeltaTime
+		// temps de jeu: rien ne s'accumule tant que le système n'est pas exécuté
+		cs.accumulator += deltaTime
 
 		cs.processEvents()
 
@@ -82,23 +71,22 @@ func (cs *CollisionSystem) Update(deltaTime float64) error {
 }
 
 func (cs *CollisionSystem) processEvents() {
-	for eventType, ch := range cs.eventChannels {
+	for {
 		select {
-		case evt, ok := <-ch:
+		case evt, ok := <-cs.events:
 			if !ok {
-				cs.eventManager.Unsubscribe(eventType, ch)
-				continue
+				return
 			}
 			if entity, ok := evt.Data.(types.GameEntity); ok {
-				switch eventType {
-				case interfaces.BulletCreated:
-					cs.AddCollidable(entity)
+				switch evt.Type {
+				case interfaces.BulletCreated, interfaces.EnemyCreated:
+					cs.addCollidable(entity)
 				default:
-					cs.RemoveCollidable(entity)
+					cs.removeCollidable(entity)
 				}
 			}
 		default:
-			// nothing
+			return
 		}
 	}
 }
@@ -108,18 +96,31 @@ func (cs *CollisionSystem) Run(ctx context.Context) error {
 }
 
 func (cs *CollisionSystem) Shutdown() {
+	if cs.events != nil {
+		cs.eventManager.Unsubscribe(interfaces.BulletCreated, cs.events)
+		cs.events = nil
+	}
 	cs.collidables = nil
 }
 
 func (cs *CollisionSystem) AddCollidable(c types.GameEntity) {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
-	cs.collidables = append(cs.collidables, c)
+	cs.addCollidable(c)
 }
 
 func (cs *CollisionSystem) RemoveCollidable(c types.GameEntity) {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
+	cs.removeCollidable(c)
+}
+
+// addCollidable et removeCollidable supposent cs.mu déjà verrouillé
+func (cs *CollisionSystem) addCollidable(c types.GameEntity) {
+	cs.collidables = append(cs.collidables, c)
+}
+
+func (cs *CollisionSystem) removeCollidable(c types.GameEntity) {
 	for i, collidable := range cs.collidables {
 		if collidable == c {
 			cs.collidables = append(cs.collidables[:i], cs.collidables[i+1:]...)
@@ -0,0 +1,94 @@
+package system
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"strings"
+
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/interfaces"
+)
+
+// intervalle entre deux vérifications du fichier, en secondes de jeu
+const configPollInterval = 1.0
+
+// ConfigReloader vérifie régulièrement le fichier de config et publie les
+// valeurs live modifiées; les autres changements sont ignorés avec un avertissement
+type ConfigReloader struct {
+	core.BaseSystem
+	eventManager interfaces.EventManagerInterface
+	watcher      *config.Watcher
+	current      config.GameConfig
+	accumulator  float64
+}
+
+func NewConfigReloader(eventManager interfaces.EventManagerInterface, watcher *config.Watcher, current config.GameConfig) *ConfigReloader {
+	return &ConfigReloader{
+		eventManager: eventManager,
+		watcher:      watcher,
+		current:      current,
+	}
+}
+
+func (cr *ConfigReloader) Initialize(ctx context.Context) error {
+	cr.CTX = ctx
+	return nil
+}
+
+func (cr *ConfigReloader) Update(deltaTime float64) error {
+	select {
+	case <-cr.CTX.Done():
+		return cr.CTX.Err()
+	default:
+		cr.accumulator += deltaTime
+		if cr.accumulator < configPollInterval {
+			return nil
+		}
+		cr.accumulator = 0
+		cr.reload()
+		return nil
+	}
+}
+
+// un fichier invalide ne doit jamais interrompre la partie: on garde la config courante
+func (cr *ConfigReloader) reload() {
+	next, changed, err := cr.watcher.Poll()
+	if err != nil {
+		log.Printf("config reload skipped: %v", err)
+		return
+	}
+	if !changed {
+		return
+	}
+	if err := next.Validate(); err != nil {
+		log.Printf("config reload rejected, %s is invalid:\n%v", cr.watcher.Path(), err)
+		return
+	}
+
+	merged, rejected := config.ApplyLive(cr.current, next)
+	if len(rejected) > 0 {
+		log.Printf("config reload: %s cannot change while the game runs, restart to apply", strings.Join(rejected, ", "))
+	}
+	if reflect.DeepEqual(merged, cr.current) {
+		return
+	}
+	cr.current = merged
+	if err := config.ChangeTopic.Publish(cr.eventManager, merged); err != nil {
+		log.Printf("failed to publish config change: %v", err)
+	}
+}
+
+// Current renvoie la dernière config appliquée
+func (cr *ConfigReloader) Current() config.GameConfig {
+	return cr.current
+}
+
+func (cr *ConfigReloader) Run(ctx context.Context) error {
+	return cr.BaseSystem.Run(ctx)
+}
+
+func (cr *ConfigReloader) Shutdown() {
+	// cleanup
+}
@@ -2,36 +2,70 @@ package system
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/input"
 	"github.com/ajkula/shmup/interfaces"
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/ajkula/shmup/topics"
+	"github.com/ajkula/shmup/types"
 )
 
+// InputSystem publie à chaque tick les actions de sa source d'entrée. Sans
+// WithInputSource aucune action n'est publiée: le clavier et les manettes
+// sont fournis par le package game, la simulation headless n'y touche pas.
 type InputSystem struct {
 	core.BaseSystem
 	eventManager interfaces.EventManagerInterface
 	accumulator  float64
+	tick         uint64
+	source       input.InputSource
+	configChan   <-chan interfaces.Event
 }
 
-func NewInputSystem(eventManager interfaces.EventManagerInterface) *InputSystem {
-	return &InputSystem{
+type InputOption func(is *InputSystem)
+
+// WithInputSource choisit la source des actions: clavier, script, replay, bot...
+func WithInputSource(source input.InputSource) InputOption {
+	return func(is *InputSystem) {
+		is.source = source
+	}
+}
+
+func NewInputSystem(eventManager interfaces.EventManagerInterface, opts ...InputOption) *InputSystem {
+	is := &InputSystem{
 		eventManager: eventManager,
 		accumulator:  0,
+		source:       input.NewScripted(nil),
+	}
+	for _, opt := range opts {
+		opt(is)
 	}
+	return is
 }
 
 func (is *InputSystem) Initialize(ctx context.Context) error {
 	is.CTX = ctx
+	var err error
+	is.configChan, err = is.eventManager.Subscribe(config.ChangeEvent)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to config changes: %w", err)
+	}
 	return nil
 }
 
+// Source renvoie la source lue à chaque tick
+func (is *InputSystem) Source() input.InputSource {
+	return is.source
+}
+
 func (is *InputSystem) Update(deltaTime float64) error {
 	select {
 	case <-is.CTX.Done():
 		return is.CTX.Err()
 	default:
+		is.applyConfigChanges()
 		is.accumulator += deltaTime
 
 		for is.accumulator >= fixedDeltaTime {
@@ -42,21 +76,38 @@ func (is *InputSystem) Update(deltaTime float64) error {
 	return nil
 }
 
-func (is *InputSystem) processInput() {
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
-		is.eventManager.Publish(interfaces.InputEvent, "shoot")
-	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) {
-		is.eventManager.Publish(interfaces.InputEvent, "up")
-	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) {
-		is.eventManager.Publish(interfaces.InputEvent, "down")
+func (is *InputSystem) applyConfigChanges() {
+	for {
+		select {
+		case evt, ok := <-is.configChan:
+			if !ok {
+				return
+			}
+			// les touches et la deadzone rechargées vont à la source qui les utilise
+			if cfg, ok := topics.ConfigChange.Payload(evt); ok {
+				if c, ok := is.source.(types.Configurable); ok {
+					c.ApplyConfig(cfg)
+				}
+			}
+		default:
+			return
+		}
 	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) {
-		is.eventManager.Publish(interfaces.InputEvent, "left")
+}
+
+func (is *InputSystem) processInput() {
+	is.tick++
+	// une action n'est publiée qu'une fois par tick, quel que soit le nombre de touches et de manettes
+	state := is.source.Poll(is.tick)
+	for _, action := range input.Actions() {
+		if state.IsPressed(action) {
+			topics.Input.Publish(is.eventManager, action.Pressed())
+		}
 	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) {
-		is.eventManager.Publish(interfaces.InputEvent, "right")
+	for _, action := range input.Actions() {
+		if state.IsHeld(action) {
+			topics.Input.Publish(is.eventManager, action.Held())
+		}
 	}
 }
 
@@ -65,5 +116,7 @@ func (is *InputSystem) Run(ctx context.Context) error {
 }
 
 func (is *InputSystem) Shutdown() {
-	// cleanup
+	if is.configChan != nil {
+		is.eventManager.Unsubscribe(config.ChangeEvent, is.configChan)
+	}
 }
@@ -0,0 +1,54 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajkula/shmup/input"
+	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/mocks"
+)
+
+func TestInputSystemPublishesSourceActions(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	source := input.NewScripted(map[uint64][]string{
+		2: {input.MoveLeft.Held(), input.Fire.Pressed(), input.MoveLeft.Held()},
+	})
+	is := NewInputSystem(eventManager, WithInputSource(source))
+	if err := is.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize returned an error: %v", err)
+	}
+	inputs, _ := eventManager.Subscribe(interfaces.InputEvent)
+
+	is.Update(fixedDeltaTime)
+	if len(inputs) != 0 {
+		t.Fatalf("No input expected on tick 1, got %d", len(inputs))
+	}
+
+	is.Update(fixedDeltaTime)
+	expected := []string{input.Fire.Pressed(), input.MoveLeft.Held()}
+	if len(inputs) != len(expected) {
+		t.Fatalf("Expected %d inputs on tick 2, got %d", len(expected), len(inputs))
+	}
+	for _, want := range expected {
+		if evt := <-inputs; evt.Data != want {
+			t.Errorf("Input: got %v, want %v", evt.Data, want)
+		}
+	}
+}
+
+func TestInputSystemWithoutSourcePublishesNothing(t *testing.T) {
+	eventManager := mocks.NewMockEventManager()
+	is := NewInputSystem(eventManager)
+	if err := is.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize returned an error: %v", err)
+	}
+	inputs, _ := eventManager.Subscribe(interfaces.InputEvent)
+
+	for i := 0; i < 10; i++ {
+		is.Update(fixedDeltaTime)
+	}
+	if len(inputs) != 0 {
+		t.Errorf("Expected no input without a source, got %d", len(inputs))
+	}
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/ajkula/shmup/core"
 	"github.com/ajkula/shmup/types"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type RenderSystem struct {
@@ -48,7 +47,7 @@ func (rs *RenderSystem) Shutdown() {
 	// cleanup
 }
 
-func (rs *RenderSystem) Render(screen *ebiten.Image) {
+func (rs *RenderSystem) Render(screen types.Screen) {
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
 	for _, entity := range rs.entities {
@@ -2,26 +2,37 @@ package system
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
+	"github.com/ajkula/shmup/config"
 	"github.com/ajkula/shmup/core"
+	"github.com/ajkula/shmup/interfaces"
 	"github.com/ajkula/shmup/types"
 )
 
 type UpdateSystem struct {
 	core.BaseSystem
-	entities []types.Updatable
-	mu       sync.Mutex
+	entities     []types.Updatable
+	eventManager interfaces.EventManagerInterface
+	configEvents <-chan interfaces.Event
+	mu           sync.Mutex
 }
 
-func NewUpdateSystem() *UpdateSystem {
+func NewUpdateSystem(eventManager interfaces.EventManagerInterface) *UpdateSystem {
 	return &UpdateSystem{
-		entities: make([]types.Updatable, 0),
+		entities:     make([]types.Updatable, 0),
+		eventManager: eventManager,
 	}
 }
 
 func (us *UpdateSystem) Initialize(ctx context.Context) error {
 	us.CTX = ctx
+	configEvents, err := config.ChangeTopic.Subscribe(us.eventManager)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to config changes: %w", err)
+	}
+	us.configEvents = configEvents
 	return nil
 }
 
@@ -32,6 +43,7 @@ func (us *UpdateSystem) Update(deltaTime float64) error {
 	default:
 		us.mu.Lock()
 		defer us.mu.Unlock()
+		us.applyConfigChanges()
 		for _, entity := range us.entities {
 			if err := entity.Update(deltaTime); err != nil {
 				return err
@@ -41,12 +53,35 @@ func (us *UpdateSystem) Update(deltaTime float64) error {
 	}
 }
 
+// applyConfigChanges suppose us.mu déjà verrouillé
+func (us *UpdateSystem) applyConfigChanges() {
+	for {
+		select {
+		case evt := <-us.configEvents:
+			cfg, ok := config.ChangeTopic.Payload(evt)
+			if !ok {
+				continue
+			}
+			for _, entity := range us.entities {
+				if c, ok := entity.(types.Configurable); ok {
+					c.ApplyConfig(cfg)
+				}
+			}
+		default:
+			return
+		}
+	}
+}
+
 func (us *UpdateSystem) Run(ctx context.Context) error {
 	return us.BaseSystem.Run(ctx)
 }
 
 func (us *UpdateSystem) Shutdown() {
-	// cleanup
+	if us.configEvents != nil {
+		us.eventManager.Unsubscribe(config.ChangeEvent, us.configEvents)
+		us.configEvents = nil
+	}
 }
 
 func (us *UpdateSystem) AddEntity(entity types.Updatable) {
@@ -0,0 +1,42 @@
+// Package topics déclare le type de charge utile de chaque événement du jeu.
+package topics
+
+import (
+	"github.com/ajkula/shmup/config"
+	"github.com/ajkula/shmup/event"
+	"github.com/ajkula/shmup/interfaces"
+	"github.com/ajkula/shmup/state"
+	"github.com/ajkula/shmup/types"
+)
+
+var (
+	GameStateChange = state.ChangeTopic
+	SceneTransition = state.SceneTransitionTopic
+	ConfigChange    = config.ChangeTopic
+
+	Input = event.NewTopic[string](interfaces.InputEvent)
+	Level = event.NewTopic[int](interfaces.LevelEvent)
+	Score = event.NewTopic[int](interfaces.ScoreEvent)
+
+	PlayerShot      = event.NewTopic[types.GameEntity](interfaces.PlayerShot)
+	PlayerDamaged   = event.NewTopic[types.GameEntity](interfaces.PlayerDamaged)
+	PlayerDestroyed = event.NewTopic[types.GameEntity](interfaces.PlayerDestroyed)
+
+	BulletCreated   = event.NewTopic[types.GameEntity](interfaces.BulletCreated)
+	BulletDestroyed = event.NewTopic[types.GameEntity](interfaces.BulletDestroyed)
+
+	EnemyCreated   = event.NewTopic[types.GameEntity](interfaces.EnemyCreated)
+	EnemyShot      = event.NewTopic[types.GameEntity](interfaces.EnemyShot)
+	EnemyDamaged   = event.NewTopic[types.GameEntity](interfaces.EnemyDamaged)
+	EnemyDestroyed = event.NewTopic[types.GameEntity](interfaces.EnemyDestroyed)
+
+	BossShot         = event.NewTopic[types.GameEntity](interfaces.BossShot)
+	BossPhaseChanged = event.NewTopic[types.GameEntity](interfaces.BossPhaseChanged)
+	BossDamaged      = event.NewTopic[types.GameEntity](interfaces.BossDamaged)
+	BossDefeated     = event.NewTopic[types.GameEntity](interfaces.BossDefeated)
+
+	EnemyAddedToFormation     = event.NewTopic[types.GameEntity](interfaces.EnemyAddedToFormation)
+	EnemyRemovedFromFormation = event.NewTopic[types.GameEntity](interfaces.EnemyRemovedFromFormation)
+	FormationCreated          = event.NewTopic[types.Formation](interfaces.FormationCreated)
+	FormationDestroyed        = event.NewTopic[types.Formation](interfaces.FormationDestroyed)
+)
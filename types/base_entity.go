@@ -1,10 +1,6 @@
 package types
 
-import (
-	"image/color"
-
-	"github.com/hajimehoshi/ebiten/v2"
-)
+import "image/color"
 
 type BaseEntity struct {
 	Position      Vector2D
@@ -53,7 +49,7 @@ func (e *BaseEntity) Update(deltaTime float64) error {
 	return nil
 }
 
-func (e *BaseEntity) Draw(screen *ebiten.Image) {
+func (e *BaseEntity) Draw(screen Screen) {
 	// default
 }
 
@@ -0,0 +1,10 @@
+package types
+
+import "image/draw"
+
+// Screen est la surface passée aux Draw. game l'implémente sur un *ebiten.Image:
+// la simulation n'importe pas ebiten et tourne sans affichage.
+type Screen interface {
+	draw.Image
+	DebugPrintAt(text string, x, y int)
+}
@@ -2,8 +2,9 @@ package types
 
 import (
 	"image/color"
+	"math"
 
-	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ajkula/shmup/config"
 )
 
 type FormationMember interface {
@@ -18,7 +19,7 @@ type Vector2D struct {
 // entité de base dans le jeu
 type Entity interface {
 	Update(deltaTime float64) error
-	Draw(screen *ebiten.Image)
+	Draw(screen Screen)
 	GetPosition() Vector2D
 	SetPosition(pos Vector2D)
 	GetSize() (width, height float64)
@@ -36,7 +37,7 @@ type Updatable interface {
 
 // peut être dessinée
 type Renderable interface {
-	Draw(screen *ebiten.Image)
+	Draw(screen Screen)
 }
 
 type Collidable interface {
@@ -44,6 +45,11 @@ type Collidable interface {
 	OnCollision(other Entity)
 }
 
+// reçoit les valeurs live de la config après un rechargement
+type Configurable interface {
+	ApplyConfig(cfg config.GameConfig)
+}
+
 // Entity / Collidable
 type GameEntity interface {
 	Entity
@@ -92,3 +98,16 @@ func (v Vector2D) Subtract(other Vector2D) Vector2D {
 func (v Vector2D) Multiply(scalar float64) Vector2D {
 	return Vector2D{v.X * scalar, v.Y * scalar}
 }
+
+func (v Vector2D) Length() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+
+// Normalize renvoie le vecteur unitaire de même direction, ou le vecteur nul
+func (v Vector2D) Normalize() Vector2D {
+	length := v.Length()
+	if length == 0 {
+		return Vector2D{}
+	}
+	return v.Multiply(1 / length)
+}